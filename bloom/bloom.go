@@ -0,0 +1,279 @@
+// Package bloom implements a thread-safe Bloom filter.
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+// magic identifies the binary serialization of a BloomFilter.
+const magic = "BLM1"
+
+// maxSerializedBits bounds the size of a filter ReadFrom accepts: 8 GiB of
+// bits, far beyond any filter worth serializing.
+const maxSerializedBits = 1 << 36
+
+var (
+	// ErrIncompatible is returned when combining filters with different
+	// sizes or numbers of hash functions.
+	ErrIncompatible = errors.New("bloom: filters have different parameters")
+	// ErrInvalidFormat is returned when decoding malformed data.
+	ErrInvalidFormat = errors.New("bloom: invalid serialized filter")
+)
+
+// BloomFilter is a thread-safe probabilistic set. MayContain never returns
+// false for an element that was added, and returns true for an element that
+// was not added with roughly the false-positive rate the filter was sized for.
+type BloomFilter[T any] struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint32 // number of hash functions
+	hash hasher.Func[T]
+	mu   sync.RWMutex
+}
+
+// New creates a filter sized for n elements at false-positive rate p, using
+// the default hasher for T.
+func New[T comparable](n uint64, p float64) *BloomFilter[T] {
+	return NewWithHasher(n, p, hasher.For[T]())
+}
+
+// NewWithHasher creates a filter sized for n elements at false-positive
+// rate p, hashing elements with h.
+func NewWithHasher[T any](n uint64, p float64, h hasher.Func[T]) *BloomFilter[T] {
+	m, k := OptimalParameters(n, p)
+	return NewWithSize(m, k, h)
+}
+
+// NewWithSize creates a filter with exactly m bits and k hash functions.
+func NewWithSize[T any](m uint64, k uint32, h hasher.Func[T]) *BloomFilter[T] {
+	if m == 0 {
+		m = 1
+	}
+	if k == 0 {
+		k = 1
+	}
+	return &BloomFilter[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		hash: h,
+	}
+}
+
+// OptimalParameters returns the number of bits and hash functions that
+// minimise the memory needed to hold n elements at false-positive rate p.
+func OptimalParameters(n uint64, p float64) (m uint64, k uint32) {
+	if n == 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+	m = uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return m, k
+}
+
+// locations derives the k bit positions of an element by double hashing.
+func (f *BloomFilter[T]) locations(elem T, fn func(uint64) bool) {
+	h1 := f.hash(elem)
+	h2 := hasher.Mix(h1) | 1
+	for i := uint32(0); i < f.k; i++ {
+		if !fn((h1 + uint64(i)*h2) % f.m) {
+			return
+		}
+	}
+}
+
+// Add inserts an element into the filter.
+func (f *BloomFilter[T]) Add(elem T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.locations(elem, func(loc uint64) bool {
+		f.bits[loc/64] |= 1 << (loc % 64)
+		return true
+	})
+}
+
+// MayContain reports whether an element may be in the filter. A false
+// result means the element was definitely never added.
+func (f *BloomFilter[T]) MayContain(elem T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	found := true
+	f.locations(elem, func(loc uint64) bool {
+		found = f.bits[loc/64]&(1<<(loc%64)) != 0
+		return found
+	})
+	return found
+}
+
+// Cap returns the number of bits in the filter.
+func (f *BloomFilter[T]) Cap() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.m
+}
+
+// K returns the number of hash functions.
+func (f *BloomFilter[T]) K() uint32 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.k
+}
+
+// Clear removes every element from the filter.
+func (f *BloomFilter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+}
+
+func (f *BloomFilter[T]) setBits() uint64 {
+	var count uint64
+	for _, w := range f.bits {
+		count += uint64(bits.OnesCount64(w))
+	}
+	return count
+}
+
+// EstimatedCardinality estimates the number of distinct elements added,
+// using the fraction of bits that are set.
+func (f *BloomFilter[T]) EstimatedCardinality() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	x := float64(f.setBits())
+	m, k := float64(f.m), float64(f.k)
+	if x >= m {
+		return uint64(m / k)
+	}
+	return uint64(math.Round(-m / k * math.Log(1-x/m)))
+}
+
+// FalsePositiveRate estimates the current false-positive probability from
+// the fraction of bits that are set.
+func (f *BloomFilter[T]) FalsePositiveRate() float64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return math.Pow(float64(f.setBits())/float64(f.m), float64(f.k))
+}
+
+// Union returns a new filter matching every element of f and other. Both
+// filters must have the same size, number of hash functions and hasher.
+func (f *BloomFilter[T]) Union(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return f.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersection returns a new filter matching elements present in both f
+// and other. Its false-positive rate is at most that of either input.
+func (f *BloomFilter[T]) Intersection(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return f.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+func (f *BloomFilter[T]) combine(other *BloomFilter[T], op func(a, b uint64) uint64) (*BloomFilter[T], error) {
+	// Snapshot other first so the two locks are never held together, and
+	// compare parameters only under f's lock, since ReadFrom can change them.
+	result := other.Copy()
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.m != result.m || f.k != result.k {
+		return nil, ErrIncompatible
+	}
+	for i := range result.bits {
+		result.bits[i] = op(f.bits[i], result.bits[i])
+	}
+	return result, nil
+}
+
+// Copy returns a new filter with the same parameters, hasher and contents.
+func (f *BloomFilter[T]) Copy() *BloomFilter[T] {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	result := NewWithSize(f.m, f.k, f.hash)
+	copy(result.bits, f.bits)
+	return result
+}
+
+// WriteTo writes the filter to w: the magic "BLM1", the number of bits and
+// hash functions, then the bit array as little-endian 64-bit words.
+func (f *BloomFilter[T]) WriteTo(w io.Writer) (int64, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	buf := make([]byte, 0, len(magic)+12+8*len(f.bits))
+	buf = append(buf, magic...)
+	buf = binary.LittleEndian.AppendUint64(buf, f.m)
+	buf = binary.LittleEndian.AppendUint32(buf, f.k)
+	for _, word := range f.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// ReadFrom replaces the parameters and contents of the filter with data
+// written by WriteTo. The hasher is kept, so it must match the one used by
+// the writer.
+func (f *BloomFilter[T]) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, len(magic)+12)
+	n, err := io.ReadFull(r, header)
+	total := int64(n)
+	if err != nil {
+		return total, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return total, fmt.Errorf("%w: bad magic %q", ErrInvalidFormat, header[:len(magic)])
+	}
+	m := binary.LittleEndian.Uint64(header[len(magic):])
+	k := binary.LittleEndian.Uint32(header[len(magic)+8:])
+	if m == 0 || m > maxSerializedBits || k == 0 {
+		return total, fmt.Errorf("%w: m=%d k=%d", ErrInvalidFormat, m, k)
+	}
+
+	// Grow words as data arrives, so a header claiming a huge filter
+	// cannot allocate more than the input actually holds.
+	count := (m + 63) / 64
+	words := make([]uint64, 0, min(count, 1<<16))
+	scratch := make([]byte, 8)
+	for uint64(len(words)) < count {
+		n, err := io.ReadFull(r, scratch)
+		total += int64(n)
+		if err != nil {
+			return total, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		words = append(words, binary.LittleEndian.Uint64(scratch))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.m, f.k, f.bits = m, k, words
+	return total, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See ReadFrom.
+func (f *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	_, err := f.ReadFrom(bytes.NewReader(data))
+	return err
+}
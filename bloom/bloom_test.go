@@ -0,0 +1,208 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+func TestAddMayContain(t *testing.T) {
+	f := New[string](1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprintf("item-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.MayContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Expected filter to contain item-%d", i)
+		}
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	const n, p = 10000, 0.01
+	f := New[int](n, p)
+	for i := 0; i < n; i++ {
+		f.Add(i)
+	}
+	falsePositives := 0
+	const trials = 100000
+	for i := n; i < n+trials; i++ {
+		if f.MayContain(i) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / trials; rate > 1.5*p {
+		t.Errorf("Expected false-positive rate near %v, got %v", p, rate)
+	}
+	if est := f.FalsePositiveRate(); math.Abs(est-p) > p {
+		t.Errorf("Expected estimated false-positive rate near %v, got %v", p, est)
+	}
+}
+
+func TestOptimalParameters(t *testing.T) {
+	m, k := OptimalParameters(1000, 0.01)
+	if m != 9586 || k != 7 {
+		t.Errorf("Expected m=9586 k=7, got m=%d k=%d", m, k)
+	}
+}
+
+func TestEstimatedCardinality(t *testing.T) {
+	f := New[int](10000, 0.01)
+	for i := 0; i < 5000; i++ {
+		f.Add(i)
+	}
+	if est := f.EstimatedCardinality(); est < 4750 || est > 5250 {
+		t.Errorf("Expected cardinality estimate near 5000, got %d", est)
+	}
+}
+
+func TestUnionIntersection(t *testing.T) {
+	a := New[string](100, 0.01)
+	b := New[string](100, 0.01)
+	a.Add("a")
+	a.Add("both")
+	b.Add("b")
+	b.Add("both")
+
+	union, err := a.Union(b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, elem := range []string{"a", "b", "both"} {
+		if !union.MayContain(elem) {
+			t.Errorf("Expected union to contain %q", elem)
+		}
+	}
+
+	inter, err := a.Intersection(b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !inter.MayContain("both") {
+		t.Errorf("Expected intersection to contain %q", "both")
+	}
+
+	c := New[string](1000, 0.01)
+	if _, err := a.Union(c); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestCustomHasher(t *testing.T) {
+	type user struct {
+		ID   int
+		Tags []string
+	}
+	f := NewWithHasher(100, 0.01, func(u user) uint64 { return hasher.Uint64(uint64(u.ID)) })
+	f.Add(user{ID: 7, Tags: []string{"admin"}})
+	if !f.MayContain(user{ID: 7}) {
+		t.Errorf("Expected filter to match users by ID")
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	f := New[string](500, 0.001)
+	for i := 0; i < 500; i++ {
+		f.Add(fmt.Sprint(i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded := New[string](1, 0.5)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Cap() != f.Cap() || decoded.K() != f.K() {
+		t.Fatalf("Expected m=%d k=%d, got m=%d k=%d", f.Cap(), f.K(), decoded.Cap(), decoded.K())
+	}
+	for i := 0; i < 500; i++ {
+		if !decoded.MayContain(fmt.Sprint(i)) {
+			t.Fatalf("Expected decoded filter to contain %d", i)
+		}
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat for truncated data, got %v", err)
+	}
+	if err := decoded.UnmarshalBinary([]byte("nope")); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat for bad data, got %v", err)
+	}
+}
+
+func TestReadFromRejectsBadSize(t *testing.T) {
+	header := func(m uint64) []byte {
+		b := append([]byte(magic), make([]byte, 12)...)
+		binary.LittleEndian.PutUint64(b[len(magic):], m)
+		binary.LittleEndian.PutUint32(b[len(magic)+8:], 3)
+		return b
+	}
+	// 0xFFFFFFFFFFFFFFC1 would round up to zero words; 1<<40 would allocate
+	// 128 GiB; 1<<20 is valid but the data is missing.
+	for _, m := range []uint64{0, 0xFFFFFFFFFFFFFFC1, 1 << 40, 1 << 20} {
+		f := New[int](10, 0.1)
+		if err := f.UnmarshalBinary(header(m)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("m=%d: expected ErrInvalidFormat, got %v", m, err)
+		}
+		f.Add(1)
+		if !f.MayContain(1) {
+			t.Errorf("m=%d: expected a failed decode to leave a working filter", m)
+		}
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	f := New[int](10000, 0.01)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				f.Add(g*1000 + i)
+				f.MayContain(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	for i := 0; i < 8000; i++ {
+		if !f.MayContain(i) {
+			t.Fatalf("Expected filter to contain %d", i)
+		}
+	}
+}
+
+// TestUnionDuringReadFrom resizes a filter with ReadFrom while it is being
+// combined with a larger one, which must fail cleanly rather than index past
+// the end of its bit array.
+func TestUnionDuringReadFrom(t *testing.T) {
+	small, _ := New[int](100, 0.01).MarshalBinary()
+	large, _ := New[int](10000, 0.01).MarshalBinary()
+	f := New[int](100, 0.01)
+	other := New[int](10000, 0.01)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2000; i++ {
+			data := small
+			if i%2 == 0 {
+				data = large
+			}
+			if err := f.UnmarshalBinary(data); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+	}()
+	for i := 0; i < 2000; i++ {
+		if _, err := f.Union(other); err != nil && !errors.Is(err, ErrIncompatible) {
+			t.Fatalf("Expected nil or ErrIncompatible, got %v", err)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,132 @@
+package bloom
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+// ErrFull is returned by CountingBloomFilter.Add when a counter would
+// overflow. The filter is left unchanged.
+var ErrFull = errors.New("bloom: counter overflow")
+
+// CountingBloomFilter is a thread-safe Bloom filter that supports deletion
+// by keeping an 8-bit counter per position instead of a single bit.
+type CountingBloomFilter[T any] struct {
+	counters []uint8
+	k        uint32
+	count    int
+	hash     hasher.Func[T]
+	mu       sync.RWMutex
+}
+
+// NewCounting creates a counting filter sized for n elements at
+// false-positive rate p, using the default hasher for T.
+func NewCounting[T comparable](n uint64, p float64) *CountingBloomFilter[T] {
+	return NewCountingWithHasher(n, p, hasher.For[T]())
+}
+
+// NewCountingWithHasher creates a counting filter sized for n elements at
+// false-positive rate p, hashing elements with h.
+func NewCountingWithHasher[T any](n uint64, p float64, h hasher.Func[T]) *CountingBloomFilter[T] {
+	m, k := OptimalParameters(n, p)
+	return &CountingBloomFilter[T]{
+		counters: make([]uint8, m),
+		k:        k,
+		hash:     h,
+	}
+}
+
+// locations returns the k counter positions of an element.
+func (f *CountingBloomFilter[T]) locations(elem T) []uint64 {
+	m := uint64(len(f.counters))
+	h1 := f.hash(elem)
+	h2 := hasher.Mix(h1) | 1
+	locs := make([]uint64, f.k)
+	for i := range locs {
+		locs[i] = (h1 + uint64(i)*h2) % m
+	}
+	return locs
+}
+
+// hits returns how many of locs are loc. An element's positions can
+// coincide, and then its counter moves by more than one.
+func hits(locs []uint64, loc uint64) int {
+	n := 0
+	for _, l := range locs {
+		if l == loc {
+			n++
+		}
+	}
+	return n
+}
+
+// Add inserts an element into the filter. It returns ErrFull if one of the
+// element's counters would overflow.
+func (f *CountingBloomFilter[T]) Add(elem T) error {
+	locs := f.locations(elem)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, loc := range locs {
+		if int(f.counters[loc])+hits(locs, loc) > math.MaxUint8 {
+			return ErrFull
+		}
+	}
+	for _, loc := range locs {
+		f.counters[loc]++
+	}
+	f.count++
+	return nil
+}
+
+// Remove deletes one occurrence of an element from the filter. It returns
+// false, leaving the filter unchanged, if the element is definitely absent.
+// Removing an element that was never added may cause false negatives.
+func (f *CountingBloomFilter[T]) Remove(elem T) bool {
+	locs := f.locations(elem)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, loc := range locs {
+		if int(f.counters[loc]) < hits(locs, loc) {
+			return false
+		}
+	}
+	for _, loc := range locs {
+		f.counters[loc]--
+	}
+	f.count--
+	return true
+}
+
+// Contains reports whether an element may be in the filter. A false result
+// means the element is definitely absent.
+func (f *CountingBloomFilter[T]) Contains(elem T) bool {
+	locs := f.locations(elem)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, loc := range locs {
+		if f.counters[loc] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of elements added and not removed.
+func (f *CountingBloomFilter[T]) Size() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.count
+}
+
+// Clear removes every element from the filter.
+func (f *CountingBloomFilter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.counters {
+		f.counters[i] = 0
+	}
+	f.count = 0
+}
@@ -0,0 +1,102 @@
+package bloom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+func TestCountingAddRemove(t *testing.T) {
+	f := NewCounting[int](1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		if err := f.Add(i); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if f.Size() != 1000 {
+		t.Errorf("Expected size 1000, got %d", f.Size())
+	}
+	for i := 0; i < 500; i++ {
+		if !f.Remove(i) {
+			t.Fatalf("Expected Remove(%d) to succeed", i)
+		}
+	}
+	for i := 500; i < 1000; i++ {
+		if !f.Contains(i) {
+			t.Fatalf("Expected filter to still contain %d", i)
+		}
+	}
+	present := 0
+	for i := 0; i < 500; i++ {
+		if f.Contains(i) {
+			present++
+		}
+	}
+	if present > 25 {
+		t.Errorf("Expected removed elements to be mostly absent, %d still match", present)
+	}
+	if f.Size() != 500 {
+		t.Errorf("Expected size 500, got %d", f.Size())
+	}
+}
+
+func TestCountingRemoveAbsent(t *testing.T) {
+	f := NewCounting[string](100, 0.01)
+	f.Add("a")
+	if f.Remove("b") {
+		t.Errorf("Expected Remove of an absent element to fail")
+	}
+	if !f.Contains("a") || f.Size() != 1 {
+		t.Errorf("Expected filter to be unchanged")
+	}
+}
+
+func TestCountingFull(t *testing.T) {
+	f := NewCountingWithHasher(10, 0.01, func(int) uint64 { return hasher.Uint64(42) })
+	for i := 0; i < 255; i++ {
+		if err := f.Add(i); err != nil {
+			t.Fatalf("Unexpected error on add %d: %v", i, err)
+		}
+	}
+	if err := f.Add(255); !errors.Is(err, ErrFull) {
+		t.Fatalf("Expected ErrFull, got %v", err)
+	}
+	if f.Size() != 255 {
+		t.Errorf("Expected failed Add to leave size at 255, got %d", f.Size())
+	}
+	f.Clear()
+	if f.Contains(1) || f.Size() != 0 {
+		t.Errorf("Expected filter to be empty after Clear")
+	}
+}
+
+func TestCountingCollidingPositions(t *testing.T) {
+	// With a single counter, both of an element's two positions land on it,
+	// so every Add moves it by two.
+	f := &CountingBloomFilter[int]{counters: make([]uint8, 1), k: 2, hash: hasher.For[int]()}
+	for i := 0; i < 127; i++ {
+		if err := f.Add(i); err != nil {
+			t.Fatalf("Unexpected error on add %d: %v", i, err)
+		}
+	}
+	if err := f.Add(127); !errors.Is(err, ErrFull) {
+		t.Fatalf("Expected ErrFull with the counter at 254, got %v", err)
+	}
+	if f.counters[0] != 254 {
+		t.Fatalf("Expected the counter to stay at 254, got %d", f.counters[0])
+	}
+	for i := 0; i < 127; i++ {
+		if !f.Remove(i) {
+			t.Fatalf("Expected remove %d to succeed", i)
+		}
+	}
+	if f.Contains(0) || f.Remove(0) || f.counters[0] != 0 {
+		t.Errorf("Expected the filter to be empty, counter is %d", f.counters[0])
+	}
+
+	f.counters[0] = 1
+	if f.Remove(0) || f.counters[0] != 1 {
+		t.Errorf("Expected Remove to refuse to take a counter of 1 below zero")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ayush-raj8/advancedDataStructure/list"
+)
+
+// read loads the input into a list and reports whether plain-text input
+// was read as numbers.
+func read(r io.Reader, format string) (*list.List, bool, error) {
+	l := list.New()
+	if format == "json" {
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		var values []any
+		if err := dec.Decode(&values); err != nil {
+			return nil, false, fmt.Errorf("reading JSON array: %w", err)
+		}
+		for _, v := range values {
+			l.Append(fromJSON(v))
+		}
+		return l, false, nil
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false, err
+	}
+	numeric := len(lines) > 0
+	for _, line := range lines {
+		if _, ok := parseNumber(line); !ok {
+			numeric = false
+			break
+		}
+	}
+	for _, line := range lines {
+		l.Append(parseValue(line, "lines", numeric))
+	}
+	return l, numeric, nil
+}
+
+// parseNumber parses s as an int, or failing that a float64.
+func parseNumber(s string) (any, bool) {
+	if i, err := strconv.Atoi(s); err == nil {
+		return i, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	return nil, false
+}
+
+// parseValue converts a command-line or input value. Plain text becomes a
+// number only when numeric is set; JSON input accepts any JSON literal and
+// treats anything else as a string.
+func parseValue(s, format string, numeric bool) any {
+	if format == "json" {
+		dec := json.NewDecoder(strings.NewReader(s))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err == nil && !dec.More() {
+			return fromJSON(v)
+		}
+		return s
+	}
+	if numeric {
+		if v, ok := parseNumber(s); ok {
+			return v
+		}
+	}
+	return s
+}
+
+// fromJSON converts decoded JSON numbers to int when integral and float64
+// otherwise, so list.List.Sort can compare them.
+func fromJSON(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.Atoi(v.String()); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = fromJSON(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = fromJSON(v[k])
+		}
+	}
+	return v
+}
+
+func write(w io.Writer, l *list.List, format string) error {
+	values := make([]any, 0, l.Len())
+	for v := range l.Iterator() {
+		values = append(values, v)
+	}
+	if format == "json" {
+		data, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	for _, v := range values {
+		var line string
+		switch v := v.(type) {
+		case string:
+			line = v
+		case float64:
+			line = strconv.FormatFloat(v, 'g', -1, 64)
+		case []any, map[string]any:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			line = string(data)
+		default:
+			line = fmt.Sprint(v)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Command listops applies list.List operations to a stream of values, so
+// shell pipelines share the Python-style semantics of the Go package.
+//
+// Usage:
+//
+//	listops [flags] OPERATION [ARG]... [OPERATION [ARG]...]...
+//
+// Operations run left to right over the whole input:
+//
+//	slice [start:end:step]  Python slice; any part may be empty, indices may be negative
+//	sort [asc|desc]         sort numbers numerically or strings lexically
+//	reverse                 reverse the order
+//	insert INDEX VALUE      insert before INDEX, which may be negative
+//	pop [INDEX]             remove the value at INDEX, by default the last
+//	dedupe                  drop repeated values, keeping the first
+//
+// Plain-text input holds one value per line. When every line is a number
+// the values are numbers, otherwise they are all strings, so sort follows
+// the same homogeneity rule as list.List.Sort. JSON input is an array whose
+// numbers, strings and booleans keep their types; sorting mixed types is an
+// error. Output uses the input format unless -out says otherwise.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flagSet(stderr)
+	in := fs.String("in", "lines", "input format: lines or json")
+	out := fs.String("out", "", "output format: lines or json (default: the input format)")
+	file := fs.String("file", "-", "input file, or - for standard input")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *out == "" {
+		*out = *in
+	}
+	fail := func(err error) int {
+		fmt.Fprintf(stderr, "listops: %v\n", err)
+		return 1
+	}
+	if err := checkFormat(*in); err != nil {
+		return fail(err)
+	}
+	if err := checkFormat(*out); err != nil {
+		return fail(err)
+	}
+	ops, err := parseOps(fs.Args(), *in)
+	if err != nil {
+		return fail(err)
+	}
+
+	r := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fail(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	l, numeric, err := read(r, *in)
+	if err != nil {
+		return fail(err)
+	}
+	for _, op := range ops {
+		if l, err = op.apply(l, numeric); err != nil {
+			return fail(fmt.Errorf("%s: %w", op.name, err))
+		}
+	}
+
+	w := bufio.NewWriter(stdout)
+	if err := write(w, l, *out); err != nil {
+		return fail(err)
+	}
+	if err := w.Flush(); err != nil {
+		return fail(err)
+	}
+	return 0
+}
+
+func checkFormat(format string) error {
+	if format != "lines" && format != "json" {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
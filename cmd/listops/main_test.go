@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runArgs(stdin string, args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), status
+}
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		args     []string
+		expected string
+	}{
+		{"slice", "a\nb\nc\nd\ne\n", []string{"slice", "[1:-1]"}, "b\nc\nd\n"},
+		{"slice step", "a\nb\nc\nd\ne\n", []string{"slice", "::2"}, "a\nc\ne\n"},
+		{"slice reverse", "a\nb\nc\n", []string{"slice", "[::-1]"}, "c\nb\na\n"},
+		{"slice negative step from end", "a\nb\nc\nd\n", []string{"slice", "[-2::-2]"}, "c\na\n"},
+		{"sort numbers", "10\n9\n-1.5\n100\n", []string{"sort"}, "-1.5\n9\n10\n100\n"},
+		{"sort strings", "10\n9\nb\n", []string{"sort", "asc"}, "10\n9\nb\n"},
+		{"sort desc", "b\na\nc\n", []string{"sort", "desc"}, "c\nb\na\n"},
+		{"reverse", "1\n2\n3\n", []string{"reverse"}, "3\n2\n1\n"},
+		{"insert", "a\nc\n", []string{"insert", "1", "b"}, "a\nb\nc\n"},
+		{"insert negative", "a\nc\n", []string{"insert", "-1", "b"}, "a\nb\nc\n"},
+		{"insert past end", "a\n", []string{"insert", "99", "z"}, "a\nz\n"},
+		{"insert before start", "a\nb\n", []string{"insert", "-100", "z"}, "z\na\nb\n"},
+		{"insert into empty", "", []string{"insert", "0", "5", "sort"}, "5\n"},
+		{"pop last", "a\nb\nc\n", []string{"pop"}, "a\nb\n"},
+		{"pop index", "a\nb\nc\n", []string{"pop", "-3"}, "b\nc\n"},
+		{"dedupe", "b\na\nb\nc\na\n", []string{"dedupe"}, "b\na\nc\n"},
+		{"pipeline", "3\n1\n2\n3\n", []string{"dedupe", "sort", "desc", "slice", "[:2]"}, "3\n2\n"},
+		{"json", `[3, "x", 1.5, true, [1]]`, []string{"-in", "json", "reverse", "pop", "0"}, `[true,1.5,"x",3]` + "\n"},
+		{"json sort", `[3, 1.5, 2]`, []string{"-in", "json", "sort"}, "[1.5,2,3]\n"},
+		{"json dedupe keeps types", `[1, "1", 1]`, []string{"-in", "json", "dedupe"}, `[1,"1"]` + "\n"},
+		{"json insert", `["a"]`, []string{"-in", "json", "insert", "0", `{"k":1}`}, `[{"k":1},"a"]` + "\n"},
+		{"json to lines", `["a", 2, 2.5]`, []string{"-in", "json", "-out", "lines", "reverse"}, "2.5\n2\na\n"},
+		{"lines to json", "2\n1\n", []string{"-out", "json", "sort"}, "[1,2]\n"},
+	}
+	for _, tt := range tests {
+		got, stderr, status := runArgs(tt.stdin, tt.args...)
+		if status != 0 {
+			t.Errorf("%s: unexpected exit status %d: %s", tt.name, status, stderr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		stdin string
+		args  []string
+	}{
+		{"a\n", nil},
+		{"a\n", []string{"shuffle"}},
+		{"a\n", []string{"slice"}},
+		{"a\n", []string{"slice", "[1]"}},
+		{"a\n", []string{"slice", "[::x]"}},
+		{"a\n", []string{"slice", "[::0]"}},
+		{"a\n", []string{"insert", "x", "b"}},
+		{"a\n", []string{"pop", "5"}},
+		{"", []string{"pop"}},
+		{`[1, "a"]`, []string{"-in", "json", "sort"}},
+		{`{"a": 1}`, []string{"-in", "json", "reverse"}},
+		{"a\n", []string{"-out", "yaml", "reverse"}},
+	}
+	for _, tt := range tests {
+		got, stderr, status := runArgs(tt.stdin, tt.args...)
+		if status == 0 || got != "" || !strings.HasPrefix(stderr, "listops: ") {
+			t.Errorf("run(%q): expected an error, got status %d, stdout %q, stderr %q", tt.args, status, got, stderr)
+		}
+	}
+}
+
+func TestFileInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(path, []byte("b\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, _, status := runArgs("", "-file", path, "sort")
+	if status != 0 || got != "a\nb\n" {
+		t.Errorf("Expected sorted file contents, got %q with status %d", got, status)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ayush-raj8/advancedDataStructure/list"
+)
+
+func flagSet(stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("listops", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: listops [flags] OPERATION [ARG]... [OPERATION [ARG]...]...")
+		fmt.Fprintln(stderr, "operations: slice [start:end:step], sort [asc|desc], reverse, insert INDEX VALUE, pop [INDEX], dedupe")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// operation is one parsed step of the pipeline. numeric reports whether
+// plain-text input was read as numbers, which decides how insert parses
+// its value.
+type operation struct {
+	name  string
+	apply func(l *list.List, numeric bool) (*list.List, error)
+}
+
+// parseOps parses the operation arguments, so usage errors are reported
+// before any input is read.
+func parseOps(args []string, format string) ([]operation, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no operations given")
+	}
+	var ops []operation
+	for len(args) > 0 {
+		name := args[0]
+		args = args[1:]
+		// optional consumes the next argument when accept allows it.
+		optional := func(accept func(string) bool) (string, bool) {
+			if len(args) > 0 && accept(args[0]) {
+				arg := args[0]
+				args = args[1:]
+				return arg, true
+			}
+			return "", false
+		}
+		switch name {
+		case "slice":
+			if len(args) == 0 {
+				return nil, errors.New("slice: missing [start:end:step]")
+			}
+			spec, err := parseSlice(args[0])
+			if err != nil {
+				return nil, err
+			}
+			args = args[1:]
+			ops = append(ops, operation{name, spec.apply})
+		case "sort":
+			order, _ := optional(func(s string) bool { return s == "asc" || s == "desc" })
+			ops = append(ops, operation{name, func(l *list.List, _ bool) (*list.List, error) {
+				if order == "" {
+					return l, l.Sort()
+				}
+				return l, l.Sort(order)
+			}})
+		case "reverse":
+			ops = append(ops, operation{name, func(l *list.List, _ bool) (*list.List, error) {
+				l.Reverse()
+				return l, nil
+			}})
+		case "insert":
+			if len(args) < 2 {
+				return nil, errors.New("insert: expected INDEX VALUE")
+			}
+			index, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("insert: invalid index %q", args[0])
+			}
+			raw := args[1]
+			args = args[2:]
+			ops = append(ops, operation{name, func(l *list.List, numeric bool) (*list.List, error) {
+				value := parseValue(raw, format, numeric || l.Len() == 0)
+				return l, l.Insert(index, value)
+			}})
+		case "pop":
+			index := -1
+			if arg, ok := optional(isInt); ok {
+				index, _ = strconv.Atoi(arg)
+			}
+			ops = append(ops, operation{name, func(l *list.List, _ bool) (*list.List, error) {
+				_, err := l.Pop(index)
+				return l, err
+			}})
+		case "dedupe":
+			ops = append(ops, operation{name, func(l *list.List, _ bool) (*list.List, error) {
+				seen := make(map[string]bool)
+				result := list.New()
+				for v := range l.Iterator() {
+					// Key by type as well as value, so 1 and "1" differ.
+					key := fmt.Sprintf("%T\x00%v", v, v)
+					if !seen[key] {
+						seen[key] = true
+						result.Append(v)
+					}
+				}
+				return result, nil
+			}})
+		default:
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+	}
+	return ops, nil
+}
+
+func isInt(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// sliceSpec is a parsed [start:end:step]; nil parts were omitted.
+type sliceSpec struct {
+	start, end, step *int
+}
+
+func parseSlice(s string) (sliceSpec, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	parts := strings.Split(inner, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return sliceSpec{}, fmt.Errorf("slice: %q is not of the form [start:end:step]", s)
+	}
+	var spec sliceSpec
+	fields := []**int{&spec.start, &spec.end, &spec.step}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return sliceSpec{}, fmt.Errorf("slice: invalid index %q in %q", part, s)
+		}
+		*fields[i] = &v
+	}
+	return spec, nil
+}
+
+// apply fills in Python's defaults for omitted parts, which depend on the
+// sign of the step, and calls list.List.Slice.
+func (spec sliceSpec) apply(l *list.List, _ bool) (*list.List, error) {
+	n := l.Len()
+	step := 1
+	if spec.step != nil {
+		step = *spec.step
+	}
+	start, end := 0, n
+	if step < 0 {
+		// -(n+1) normalizes to -1: just before the first value.
+		start, end = n-1, -(n + 1)
+	}
+	if spec.start != nil {
+		start = *spec.start
+	}
+	if spec.end != nil {
+		end = *spec.end
+	}
+	return l.Slice(start, end, step)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readValues calls emit with every value of the file at path.
+func (opts *options) readValues(path string, emit func(string) error) error {
+	r, err := opts.open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	switch format := opts.formatOf(path); format {
+	case "lines":
+		err = readLines(r, emit)
+	case "csv":
+		err = readCSV(r, opts.column, opts.header, emit)
+	case "json":
+		err = readJSON(r, emit)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func readLines(r io.Reader, emit func(string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if v := strings.TrimSpace(scanner.Text()); v != "" {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// readCSV emits one column, given as a 1-based index or a header name.
+func readCSV(r io.Reader, column string, header bool, emit func(string) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	index, err := strconv.Atoi(column)
+	byName := err != nil
+	if !byName && index < 1 {
+		return fmt.Errorf("invalid CSV column %d", index)
+	}
+	index--
+	if byName || header {
+		names, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if byName {
+			index = -1
+			for i, name := range names {
+				if strings.TrimSpace(name) == column {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				return fmt.Errorf("no CSV column named %q", column)
+			}
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if index >= len(record) {
+			continue
+		}
+		if v := strings.TrimSpace(record[index]); v != "" {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readJSON emits the elements of a JSON array of strings and numbers,
+// decoding one element at a time. Numbers keep their literal text. Decode
+// errors are wrapped with the byte offset the decoder had reached.
+func readJSON(r io.Reader, emit func(string) error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	t, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("JSON at offset %d: %w", dec.InputOffset(), err)
+	}
+	if t != json.Delim('[') {
+		return fmt.Errorf("expected a JSON array, got %v", t)
+	}
+	for i := 0; dec.More(); i++ {
+		var elem any
+		if err := dec.Decode(&elem); err != nil {
+			return fmt.Errorf("JSON array element %d at offset %d: %w", i, dec.InputOffset(), err)
+		}
+		var v string
+		switch e := elem.(type) {
+		case string:
+			v = e
+		case json.Number:
+			v = e.String()
+		default:
+			return fmt.Errorf("array element %d is not a string or number", i)
+		}
+		if v = strings.TrimSpace(v); v != "" {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("JSON at offset %d: %w", dec.InputOffset(), err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Command setops computes set operations over files of values, as a
+// replacement for "sort | comm" pipelines.
+//
+// Usage:
+//
+//	setops [flags] OPERATION FILE...
+//
+// OPERATION is one of:
+//
+//	union         values in any file
+//	intersection  values in every file
+//	difference    values in the first file and in no other
+//	symdiff       values in an odd number of files
+//	subset        whether the first file is a subset of every other file
+//	disjoint      whether no value appears in more than one file
+//
+// subset and disjoint print "true" or "false" and exit with status 0 or 1
+// accordingly; errors exit with status 2. A FILE of "-" reads standard
+// input.
+//
+// Each file holds one value per line, one CSV column, or a JSON array of
+// strings and numbers, chosen by -format or by the file extension. Values
+// are trimmed of surrounding whitespace and blank values are ignored.
+//
+// By default every file is loaded into a set.Set[string]. With -stream,
+// each file is instead sorted in chunks of -chunk values into run files on
+// disk, the runs are merged, and the operation is computed by merging the
+// sorted files, so memory use is bounded by the chunk size rather than the
+// input size. Streamed output is always sorted.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exit statuses.
+const (
+	exitTrue  = 0
+	exitFalse = 1
+	exitError = 2
+)
+
+type options struct {
+	op     string
+	files  []string
+	format string
+	column string
+	header bool
+	sorted bool
+	count  bool
+	stream bool
+	chunk  int
+	tmpDir string
+	stdin  io.Reader
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("setops", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts := options{stdin: stdin}
+	fs.StringVar(&opts.format, "format", "auto", "input format: lines, csv, json, or auto to choose by file extension")
+	fs.StringVar(&opts.column, "column", "1", "CSV column: a 1-based index, or a header name")
+	fs.BoolVar(&opts.header, "header", false, "skip the first CSV row (implied when -column is a name)")
+	fs.BoolVar(&opts.sorted, "sorted", true, "sort the output")
+	fs.BoolVar(&opts.count, "count", false, "print only the number of values in the result")
+	fs.BoolVar(&opts.stream, "stream", false, "sort inputs on disk instead of loading them into memory")
+	fs.IntVar(&opts.chunk, "chunk", 1000000, "values per on-disk run when streaming")
+	fs.StringVar(&opts.tmpDir, "tmpdir", "", "directory for on-disk runs (default: the system temporary directory)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: setops [flags] union|intersection|difference|symdiff|subset|disjoint FILE...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return exitError
+	}
+	opts.op, opts.files = fs.Arg(0), fs.Args()[1:]
+	if _, ok := operations[opts.op]; !ok {
+		fmt.Fprintf(stderr, "setops: unknown operation %q\n", opts.op)
+		return exitError
+	}
+	if opts.chunk < 1 {
+		fmt.Fprintln(stderr, "setops: -chunk must be positive")
+		return exitError
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+	var (
+		result bool
+		err    error
+	)
+	if opts.stream {
+		result, err = runStream(opts, out)
+	} else {
+		result, err = runMemory(opts, out)
+	}
+	if err != nil {
+		out.Flush()
+		fmt.Fprintf(stderr, "setops: %v\n", err)
+		return exitError
+	}
+	if !result {
+		return exitFalse
+	}
+	return exitTrue
+}
+
+// operation describes how to combine N inputs. keep decides membership of
+// a value from the number of inputs holding it and whether the first does.
+type operation struct {
+	check bool // subset and disjoint print a verdict instead of values
+	keep  func(count, n int, inFirst bool) bool
+}
+
+var operations = map[string]operation{
+	"union":        {keep: func(count, n int, inFirst bool) bool { return count > 0 }},
+	"intersection": {keep: func(count, n int, inFirst bool) bool { return count == n }},
+	"difference":   {keep: func(count, n int, inFirst bool) bool { return inFirst && count == 1 }},
+	"symdiff":      {keep: func(count, n int, inFirst bool) bool { return count%2 == 1 }},
+	// For checks, keep reports whether a value violates the property.
+	"subset":   {check: true, keep: func(count, n int, inFirst bool) bool { return inFirst && count < n }},
+	"disjoint": {check: true, keep: func(count, n int, inFirst bool) bool { return count > 1 }},
+}
+
+// writeResult prints values, or their count, and returns nil.
+func writeResult(w io.Writer, opts options, values []string) error {
+	if opts.count {
+		_, err := fmt.Fprintln(w, len(values))
+		return err
+	}
+	if opts.sorted {
+		sort.Strings(values)
+	}
+	for _, v := range values {
+		if _, err := fmt.Fprintln(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeVerdict(w io.Writer, ok bool) (bool, error) {
+	_, err := fmt.Fprintln(w, ok)
+	return ok, err
+}
+
+// open returns a reader for a file argument, where "-" is standard input.
+func (opts *options) open(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		if opts.stdin == nil {
+			return nil, errors.New("standard input can only be read once")
+		}
+		r := io.NopCloser(opts.stdin)
+		opts.stdin = nil
+		return r, nil
+	}
+	return os.Open(path)
+}
+
+// formatOf resolves the input format of a file.
+func (opts options) formatOf(path string) string {
+	if opts.format != "auto" {
+		return opts.format
+	}
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return "csv"
+	case strings.HasSuffix(path, ".json"):
+		return "json"
+	default:
+		return "lines"
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func runArgs(t *testing.T, stdin string, args ...string) (string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	if status == exitError {
+		t.Logf("stderr: %s", stderr.String())
+	}
+	return stdout.String(), status
+}
+
+// TestGolden runs each case in memory and streamed through small on-disk
+// runs, and compares both outputs with testdata/<name>.golden.
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+	}{
+		{"union", []string{"-column", "id", "union", "a.txt", "b.csv", "c.json"}, exitTrue},
+		{"intersection", []string{"-column", "id", "intersection", "a.txt", "b.csv"}, exitTrue},
+		{"difference", []string{"-column", "2", "-header", "difference", "a.txt", "b.csv", "c.json"}, exitTrue},
+		{"symdiff", []string{"-column", "id", "symdiff", "a.txt", "b.csv", "c.json"}, exitTrue},
+		{"union_count", []string{"-count", "-column", "id", "union", "a.txt", "b.csv", "c.json"}, exitTrue},
+		{"subset_true", []string{"subset", "d.txt", "a.txt"}, exitTrue},
+		{"subset_false", []string{"subset", "a.txt", "d.txt"}, exitFalse},
+		{"disjoint_true", []string{"disjoint", "d.txt", "e.txt"}, exitTrue},
+		{"disjoint_false", []string{"disjoint", "e.txt", "d.txt", "a.txt"}, exitFalse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden := filepath.Join("testdata", tt.name+".golden")
+			args := make([]string, 0, len(tt.args))
+			for _, a := range tt.args {
+				if strings.Contains(a, ".") {
+					a = filepath.Join("testdata", a)
+				}
+				args = append(args, a)
+			}
+
+			got, status := runArgs(t, "", args...)
+			if status != tt.status {
+				t.Errorf("Expected exit status %d, got %d", tt.status, status)
+			}
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("In-memory output differs from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+
+			streamed, status := runArgs(t, "", append([]string{"-stream", "-chunk", "2", "-tmpdir", t.TempDir()}, args...)...)
+			if status != tt.status {
+				t.Errorf("Expected streamed exit status %d, got %d", tt.status, status)
+			}
+			if streamed != string(want) {
+				t.Errorf("Streamed output differs from %s:\ngot:\n%s\nwant:\n%s", golden, streamed, want)
+			}
+		})
+	}
+}
+
+func TestUnsortedOutput(t *testing.T) {
+	got, status := runArgs(t, "", "-sorted=false", "union", "testdata/d.txt", "testdata/e.txt")
+	if status != exitTrue {
+		t.Fatalf("Expected success, got status %d", status)
+	}
+	lines := strings.Fields(got)
+	sort.Strings(lines)
+	if strings.Join(lines, " ") != "u2 u3 x1 x2" {
+		t.Errorf("Expected the union of d and e, got %q", got)
+	}
+}
+
+func TestStdin(t *testing.T) {
+	got, _ := runArgs(t, "u3\nu9\n", "intersection", "-", "testdata/d.txt")
+	if got != "u3\n" {
+		t.Errorf("Expected u3, got %q", got)
+	}
+	_, status := runArgs(t, "u3\n", "union", "-", "-")
+	if status != exitError {
+		t.Errorf("Expected reading standard input twice to fail, got status %d", status)
+	}
+}
+
+func TestStreamManyRuns(t *testing.T) {
+	// Enough values for more than maxFanIn runs, forcing a multi-pass merge.
+	var input strings.Builder
+	for i := 0; i < 3*maxFanIn; i++ {
+		input.WriteString(strings.Repeat("v", i%7+1) + "\n")
+	}
+	got, status := runArgs(t, input.String(), "-stream", "-chunk", "1", "-tmpdir", t.TempDir(), "-count", "union", "-", "testdata/e.txt")
+	if status != exitTrue || got != "9\n" {
+		t.Errorf("Expected 9 distinct values, got %q with status %d", got, status)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := [][]string{
+		{"union"},
+		{"frobnicate", "testdata/a.txt", "testdata/d.txt"},
+		{"union", "testdata/missing.txt", "testdata/a.txt"},
+		{"-format", "json", "union", "testdata/a.txt", "testdata/d.txt"},
+		{"-column", "nope", "union", "testdata/b.csv", "testdata/d.txt"},
+		{"-chunk", "0", "union", "testdata/a.txt", "testdata/d.txt"},
+	}
+	for _, args := range tests {
+		if _, status := runArgs(t, "", args...); status != exitError {
+			t.Errorf("run(%q): expected exit status %d, got %d", args, exitError, status)
+		}
+	}
+	got, status := runArgs(t, `[1, {"a": 2}]`, "-format", "json", "union", "-", "testdata/d.txt")
+	if status != exitError || got != "" {
+		t.Errorf("Expected an error for a JSON object element, got %q with status %d", got, status)
+	}
+}
+
+func TestJSONErrorDetail(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"-format", "json", "union", "-", "testdata/d.txt"}, strings.NewReader(`["a", "b" "c"]`), &stdout, &stderr)
+	if status != exitError {
+		t.Fatalf("Expected exit status %d, got %d", exitError, status)
+	}
+	// The message names the input, the offset and the decoder's own error.
+	for _, want := range []string{"-: ", "offset 10", "invalid character"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Expected %q in %q", want, stderr.String())
+		}
+	}
+
+	err := readJSON(strings.NewReader(`[1, 2`), func(string) error { return nil })
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected the decode error to be wrapped, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"io"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// runMemory loads every file into a set.Set[string] and combines them with
+// the set methods.
+func runMemory(opts options, w io.Writer) (bool, error) {
+	sets := make([]*set.Set[string], len(opts.files))
+	for i, path := range opts.files {
+		s := set.New[string]()
+		err := opts.readValues(path, func(v string) error {
+			s.Add(v)
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+		sets[i] = s
+	}
+
+	first, rest := sets[0], sets[1:]
+	switch opts.op {
+	case "subset":
+		for _, other := range rest {
+			if !first.IsSubset(other) {
+				return writeVerdict(w, false)
+			}
+		}
+		return writeVerdict(w, true)
+	case "disjoint":
+		for i := range sets {
+			for _, other := range sets[i+1:] {
+				if !sets[i].IsDisjoint(other) {
+					return writeVerdict(w, false)
+				}
+			}
+		}
+		return writeVerdict(w, true)
+	}
+
+	result := first
+	for _, other := range rest {
+		switch opts.op {
+		case "union":
+			result = result.Union(other)
+		case "intersection":
+			result = result.Intersection(other)
+		case "difference":
+			result = result.Difference(other)
+		case "symdiff":
+			result = result.SymmetricDifference(other)
+		}
+	}
+	return true, writeResult(w, opts, result.ToSlice())
+}
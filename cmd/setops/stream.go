@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxFanIn bounds the number of run files merged, and so held open, at once.
+const maxFanIn = 64
+
+// Run files hold one value per line in ascending byte order without
+// duplicates. Backslashes and newlines inside values are escaped as \\ and
+// \n, so every record is exactly one line.
+var (
+	escaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	unescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n")
+)
+
+// runStream sorts every input into a run file on disk and computes the
+// operation by merging them, holding one value per input in memory.
+func runStream(opts options, w io.Writer) (bool, error) {
+	dir, err := os.MkdirTemp(opts.tmpDir, "setops-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(dir)
+
+	runs := make([]string, len(opts.files))
+	for i, path := range opts.files {
+		if runs[i], err = opts.sortToDisk(path, dir); err != nil {
+			return false, err
+		}
+	}
+
+	op := operations[opts.op]
+	n, count, violated := len(runs), 0, false
+	err = mergeRuns(runs, func(v string, present []bool) (bool, error) {
+		holders := 0
+		for _, p := range present {
+			if p {
+				holders++
+			}
+		}
+		if !op.keep(holders, n, present[0]) {
+			return true, nil
+		}
+		if op.check {
+			violated = true
+			return false, nil
+		}
+		count++
+		if opts.count {
+			return true, nil
+		}
+		_, err := fmt.Fprintln(w, v)
+		return true, err
+	})
+	if err != nil {
+		return false, err
+	}
+	if op.check {
+		return writeVerdict(w, !violated)
+	}
+	if opts.count {
+		_, err = fmt.Fprintln(w, count)
+	}
+	return true, err
+}
+
+// sortToDisk writes the values of the file at path to a single sorted run
+// file in dir and returns its name. Values are sorted in memory in chunks
+// of opts.chunk, and the resulting runs are merged at most maxFanIn at a
+// time.
+func (opts *options) sortToDisk(path, dir string) (string, error) {
+	var runs []string
+	chunk := make([]string, 0, opts.chunk)
+	flush := func() error {
+		sort.Strings(chunk)
+		run, err := writeRun(dir, func(write func(string) error) error {
+			for i, v := range chunk {
+				if i > 0 && v == chunk[i-1] {
+					continue
+				}
+				if err := write(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		runs = append(runs, run)
+		chunk = chunk[:0]
+		return err
+	}
+	err := opts.readValues(path, func(v string) error {
+		chunk = append(chunk, v)
+		if len(chunk) == opts.chunk {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(chunk) > 0 || len(runs) == 0 {
+		if err := flush(); err != nil {
+			return "", err
+		}
+	}
+
+	for len(runs) > 1 {
+		var merged []string
+		for start := 0; start < len(runs); start += maxFanIn {
+			group := runs[start:min(start+maxFanIn, len(runs))]
+			run, err := writeRun(dir, func(write func(string) error) error {
+				return mergeRuns(group, func(v string, _ []bool) (bool, error) {
+					return true, write(v)
+				})
+			})
+			if err != nil {
+				return "", err
+			}
+			for _, old := range group {
+				os.Remove(old)
+			}
+			merged = append(merged, run)
+		}
+		runs = merged
+	}
+	return runs[0], nil
+}
+
+// writeRun creates a run file in dir and fills it through fill.
+func writeRun(dir string, fill func(write func(string) error) error) (string, error) {
+	f, err := os.CreateTemp(dir, "run-")
+	if err != nil {
+		return "", err
+	}
+	bw := bufio.NewWriter(f)
+	err = fill(func(v string) error {
+		_, err := bw.WriteString(escaper.Replace(v) + "\n")
+		return err
+	})
+	if err == nil {
+		err = bw.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return f.Name(), err
+}
+
+type cursor struct {
+	r     *bufio.Reader
+	value string
+	index int
+}
+
+// next advances the cursor, reporting false at the end of the run.
+func (c *cursor) next() (bool, error) {
+	line, err := c.r.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return false, nil
+	}
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	c.value = unescaper.Replace(strings.TrimSuffix(line, "\n"))
+	return true, nil
+}
+
+type cursorHeap []*cursor
+
+func (h cursorHeap) Len() int           { return len(h) }
+func (h cursorHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h cursorHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *cursorHeap) Push(x any)        { *h = append(*h, x.(*cursor)) }
+func (h *cursorHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// mergeRuns visits every distinct value of the run files in ascending
+// order, reporting which runs hold it, until visit returns false.
+func mergeRuns(runs []string, visit func(value string, present []bool) (bool, error)) error {
+	h := make(cursorHeap, 0, len(runs))
+	for i, run := range runs {
+		f, err := os.Open(run)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		c := &cursor{r: bufio.NewReader(f), index: i}
+		ok, err := c.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h = append(h, c)
+		}
+	}
+	heap.Init(&h)
+	present := make([]bool, len(runs))
+	for h.Len() > 0 {
+		value := h[0].value
+		for i := range present {
+			present[i] = false
+		}
+		for h.Len() > 0 && h[0].value == value {
+			c := h[0]
+			present[c.index] = true
+			ok, err := c.next()
+			if err != nil {
+				return err
+			}
+			if ok {
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+			}
+		}
+		more, err := visit(value, present)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
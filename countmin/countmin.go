@@ -0,0 +1,166 @@
+// Package countmin implements a thread-safe count-min sketch for
+// estimating element frequencies in a stream.
+package countmin
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+// ErrIncompatible is returned when merging sketches of different sizes.
+var ErrIncompatible = errors.New("countmin: sketches have different dimensions")
+
+// Sketch is a thread-safe count-min sketch. Estimates never undercount;
+// with probability 1-delta they overcount by at most epsilon times the
+// total of all counts.
+type Sketch[T any] struct {
+	width, depth int
+	counts       []uint64 // depth rows of width counters
+	conservative bool
+	total        uint64
+	hash         hasher.Func[T]
+	mu           sync.RWMutex
+}
+
+// New creates a sketch with error bound epsilon and failure probability
+// delta, using the default hasher for T.
+func New[T comparable](epsilon, delta float64) *Sketch[T] {
+	width, depth := Dimensions(epsilon, delta)
+	return NewWithHasher(width, depth, false, hasher.For[T]())
+}
+
+// NewConservative is like New but uses conservative update, which only
+// raises the counters that hold the current minimum. This lowers the
+// overestimate considerably at the cost of slightly slower updates.
+func NewConservative[T comparable](epsilon, delta float64) *Sketch[T] {
+	width, depth := Dimensions(epsilon, delta)
+	return NewWithHasher(width, depth, true, hasher.For[T]())
+}
+
+// NewWithHasher creates a sketch with the given dimensions and update
+// mode, hashing elements with h.
+func NewWithHasher[T any](width, depth int, conservative bool, h hasher.Func[T]) *Sketch[T] {
+	if width < 1 {
+		width = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return &Sketch[T]{
+		width:        width,
+		depth:        depth,
+		counts:       make([]uint64, width*depth),
+		conservative: conservative,
+		hash:         h,
+	}
+}
+
+// Dimensions returns the width ceil(e/epsilon) and depth ceil(ln(1/delta))
+// needed for the given error bound and failure probability. Parameters
+// outside (0, 1) are replaced by 0.01.
+func Dimensions(epsilon, delta float64) (width, depth int) {
+	if !(epsilon > 0 && epsilon < 1) {
+		epsilon = 0.01
+	}
+	if !(delta > 0 && delta < 1) {
+		delta = 0.01
+	}
+	width = int(math.Ceil(math.E / epsilon))
+	depth = int(math.Ceil(math.Log(1 / delta)))
+	return width, depth
+}
+
+// cells returns the counter index of an element in every row.
+func (s *Sketch[T]) cells(elem T) []int {
+	h1 := s.hash(elem)
+	h2 := hasher.Mix(h1) | 1
+	cells := make([]int, s.depth)
+	for row := range cells {
+		cells[row] = row*s.width + int((h1+uint64(row)*h2)%uint64(s.width))
+	}
+	return cells
+}
+
+// Add records one occurrence of an element.
+func (s *Sketch[T]) Add(elem T) {
+	s.AddCount(elem, 1)
+}
+
+// AddCount records n occurrences of an element.
+func (s *Sketch[T]) AddCount(elem T, n uint64) {
+	cells := s.cells(elem)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total += n
+	if !s.conservative {
+		for _, c := range cells {
+			s.counts[c] += n
+		}
+		return
+	}
+	target := s.min(cells) + n
+	for _, c := range cells {
+		if s.counts[c] < target {
+			s.counts[c] = target
+		}
+	}
+}
+
+// Estimate returns the estimated number of occurrences of an element.
+func (s *Sketch[T]) Estimate(elem T) uint64 {
+	cells := s.cells(elem)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.min(cells)
+}
+
+func (s *Sketch[T]) min(cells []int) uint64 {
+	result := uint64(math.MaxUint64)
+	for _, c := range cells {
+		if s.counts[c] < result {
+			result = s.counts[c]
+		}
+	}
+	return result
+}
+
+// Total returns the sum of all counts added.
+func (s *Sketch[T]) Total() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total
+}
+
+// Merge adds the counts of other into s. Both sketches must have the same
+// dimensions and hasher. Merging keeps the never-undercount guarantee.
+func (s *Sketch[T]) Merge(other *Sketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	// Snapshot other first so the two locks are never held together.
+	other.mu.RLock()
+	counts := append([]uint64(nil), other.counts...)
+	total := other.total
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range counts {
+		s.counts[i] += c
+	}
+	s.total += total
+	return nil
+}
+
+// Clear resets every counter to zero.
+func (s *Sketch[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.counts {
+		s.counts[i] = 0
+	}
+	s.total = 0
+}
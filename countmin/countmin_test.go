@@ -0,0 +1,129 @@
+package countmin
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// zipfStream returns a skewed stream and its exact counts.
+func zipfStream(n int) ([]uint64, map[uint64]uint64) {
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 100000)
+	stream := make([]uint64, n)
+	exact := map[uint64]uint64{}
+	for i := range stream {
+		stream[i] = zipf.Uint64()
+		exact[stream[i]]++
+	}
+	return stream, exact
+}
+
+func TestDimensions(t *testing.T) {
+	tests := []struct {
+		epsilon, delta float64
+		width, depth   int
+	}{
+		{0.001, 0.01, 2719, 5},
+		{0, 0.01, 272, 5},
+		{-1, 0.01, 272, 5},
+		{0.01, 0, 272, 5},
+		{0.01, 1, 272, 5},
+		{math.NaN(), 2, 272, 5},
+	}
+	for _, tt := range tests {
+		if width, depth := Dimensions(tt.epsilon, tt.delta); width != tt.width || depth != tt.depth {
+			t.Errorf("Dimensions(%v, %v) = %d, %d; want %d, %d", tt.epsilon, tt.delta, width, depth, tt.width, tt.depth)
+		}
+	}
+}
+
+func TestErrorBound(t *testing.T) {
+	const epsilon = 0.001
+	stream, exact := zipfStream(200000)
+	for _, s := range []*Sketch[uint64]{New[uint64](epsilon, 0.01), NewConservative[uint64](epsilon, 0.01)} {
+		for _, v := range stream {
+			s.Add(v)
+		}
+		bound := uint64(epsilon * float64(s.Total()))
+		violations := 0
+		for v, count := range exact {
+			est := s.Estimate(v)
+			if est < count {
+				t.Fatalf("Estimate %d undercounts exact %d", est, count)
+			}
+			if est-count > bound {
+				violations++
+			}
+		}
+		if float64(violations) > 0.01*float64(len(exact)) {
+			t.Errorf("Expected at most 1%% of estimates beyond the bound, got %d of %d", violations, len(exact))
+		}
+	}
+}
+
+func TestConservativeIsTighter(t *testing.T) {
+	stream, exact := zipfStream(100000)
+	plain := New[uint64](0.01, 0.01)
+	conservative := NewConservative[uint64](0.01, 0.01)
+	for _, v := range stream {
+		plain.Add(v)
+		conservative.Add(v)
+	}
+	var plainErr, conservativeErr uint64
+	for v, count := range exact {
+		plainErr += plain.Estimate(v) - count
+		conservativeErr += conservative.Estimate(v) - count
+	}
+	if conservativeErr >= plainErr {
+		t.Errorf("Expected conservative update to reduce error, %d >= %d", conservativeErr, plainErr)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := New[string](0.01, 0.01)
+	b := New[string](0.01, 0.01)
+	a.AddCount("x", 5)
+	b.AddCount("x", 7)
+	b.Add("y")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if est := a.Estimate("x"); est != 12 {
+		t.Errorf("Expected merged estimate 12, got %d", est)
+	}
+	if a.Total() != 13 {
+		t.Errorf("Expected total 13, got %d", a.Total())
+	}
+	if err := a.Merge(New[string](0.1, 0.01)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+	a.Clear()
+	if a.Estimate("x") != 0 || a.Total() != 0 {
+		t.Errorf("Expected sketch to be empty after Clear")
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	s := NewConservative[int](0.01, 0.01)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				s.Add(i % 10)
+				s.Estimate(i % 10)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Total() != 8000 {
+		t.Errorf("Expected total 8000, got %d", s.Total())
+	}
+	if est := s.Estimate(3); est < 800 {
+		t.Errorf("Expected estimate of at least 800, got %d", est)
+	}
+}
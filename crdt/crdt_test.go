@@ -0,0 +1,216 @@
+package crdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// replica is the subset of the CRDT API the property tests need.
+type replica[R any] interface {
+	Merge(other R)
+	Equal(other R) bool
+}
+
+// checkMergeProperties verifies that merge is commutative, associative and
+// idempotent for three replica states produced by gen.
+func checkMergeProperties[R replica[R]](t *testing.T, gen func(rng *rand.Rand, id string) R, fresh func() R) {
+	t.Helper()
+	merged := func(states ...R) R {
+		result := fresh()
+		for _, s := range states {
+			result.Merge(s)
+		}
+		return result
+	}
+	for seed := int64(0); seed < 100; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		a, b, c := gen(rng, "a"), gen(rng, "b"), gen(rng, "c")
+
+		if ab, ba := merged(a, b), merged(b, a); !ab.Equal(ba) {
+			t.Fatalf("seed %d: merge is not commutative", seed)
+		}
+
+		left := merged(merged(a, b), c)
+		right := merged(a, merged(b, c))
+		if !left.Equal(right) {
+			t.Fatalf("seed %d: merge is not associative", seed)
+		}
+
+		aa := merged(a)
+		aa.Merge(a)
+		if !aa.Equal(merged(a)) {
+			t.Fatalf("seed %d: merge is not idempotent", seed)
+		}
+	}
+}
+
+func randomGSet(rng *rand.Rand, _ string) *GSet[int] {
+	g := NewGSet[int]()
+	for i := 0; i < rng.Intn(20); i++ {
+		g.Add(rng.Intn(30))
+	}
+	return g
+}
+
+func randomTwoPSet(rng *rand.Rand, _ string) *TwoPSet[int] {
+	s := NewTwoPSet[int]()
+	for i := 0; i < rng.Intn(30); i++ {
+		if rng.Intn(3) == 0 {
+			s.Remove(rng.Intn(30))
+		} else {
+			s.Add(rng.Intn(30))
+		}
+	}
+	return s
+}
+
+// randomORSet builds a replica that has also observed part of a shared
+// history, so removals can target tags created elsewhere.
+func randomORSet(rng *rand.Rand, id string) *ORSet[int] {
+	shared := NewORSet[int]("shared")
+	for i := 0; i < 10; i++ {
+		shared.Add(i)
+	}
+	o := NewORSet[int](id)
+	o.Merge(shared)
+	for i := 0; i < rng.Intn(30); i++ {
+		if rng.Intn(3) == 0 {
+			o.Remove(rng.Intn(15))
+		} else {
+			o.Add(rng.Intn(15))
+		}
+	}
+	return o
+}
+
+func TestMergeProperties(t *testing.T) {
+	t.Run("GSet", func(t *testing.T) {
+		checkMergeProperties(t, randomGSet, NewGSet[int])
+	})
+	t.Run("TwoPSet", func(t *testing.T) {
+		checkMergeProperties(t, randomTwoPSet, NewTwoPSet[int])
+	})
+	t.Run("ORSet", func(t *testing.T) {
+		checkMergeProperties(t, randomORSet, func() *ORSet[int] { return NewORSet[int]("merge") })
+	})
+}
+
+func TestDeltaMatchesFullMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	source := NewORSet[int]("source")
+	viaDeltas := NewORSet[int]("deltas")
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 5; i++ {
+			if rng.Intn(3) == 0 {
+				source.Remove(rng.Intn(10))
+			} else {
+				source.Add(rng.Intn(10))
+			}
+		}
+		viaDeltas.Merge(source.Delta())
+	}
+	viaState := NewORSet[int]("deltas")
+	viaState.Merge(source)
+	if !viaDeltas.Equal(viaState) {
+		t.Fatalf("Expected deltas to converge to the full state, got %v and %v",
+			viaDeltas.Elements().ToSlice(), viaState.Elements().ToSlice())
+	}
+	for _, o := range []*ORSet[int]{source, viaDeltas, viaState} {
+		checkOwners(t, o)
+	}
+}
+
+// checkOwners verifies that the tag index matches the entries exactly.
+func checkOwners(t *testing.T, o *ORSet[int]) {
+	t.Helper()
+	live := 0
+	for elem, tags := range o.entries {
+		live += tags.Size()
+		tags.ForEach(func(tag Tag) {
+			if owner, ok := o.owners[tag]; !ok || owner != elem {
+				t.Fatalf("Replica %s: tag %v of %d is indexed under %d, %v", o.replica, tag, elem, owner, ok)
+			}
+		})
+	}
+	if len(o.owners) != live {
+		t.Fatalf("Replica %s: %d tags indexed, %d live", o.replica, len(o.owners), live)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	g, g2 := randomGSet(rng, ""), NewGSet[int]()
+	p, p2 := randomTwoPSet(rng, ""), NewTwoPSet[int]()
+	o, o2 := randomORSet(rng, "x"), NewORSet[int]("y")
+
+	tests := []struct {
+		name    string
+		state   any
+		decoded any
+		equal   func() bool
+	}{
+		{"GSet", g, g2, func() bool { return g.Equal(g2) }},
+		{"TwoPSet", p, p2, func() bool { return p.Equal(p2) }},
+		{"ORSet", o, o2, func() bool { return o.Equal(o2) && o2.Replica() == "x" }},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.state)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if err := json.Unmarshal(data, tt.decoded); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.equal() {
+			t.Errorf("%s: decoded state differs from %s", tt.name, data)
+		}
+	}
+}
+
+func TestTwoPSetRemoveIsPermanent(t *testing.T) {
+	s := NewTwoPSet[string]()
+	if s.Remove("a") {
+		t.Errorf("Expected Remove of an absent element to fail")
+	}
+	s.Add("a")
+	if !s.Remove("a") {
+		t.Errorf("Expected Remove to succeed")
+	}
+	s.Add("a")
+	if s.Contains("a") || s.Size() != 0 {
+		t.Errorf("Expected a removed element to stay removed")
+	}
+}
+
+func TestORSetReAdd(t *testing.T) {
+	o := NewORSet[string]("a")
+	o.Add("x")
+	if !o.Remove("x") || o.Contains("x") {
+		t.Errorf("Expected x to be removed")
+	}
+	o.Add("x")
+	if !o.Contains("x") || o.Size() != 1 {
+		t.Errorf("Expected x to be re-added")
+	}
+	if o.Remove("y") {
+		t.Errorf("Expected Remove of an absent element to fail")
+	}
+}
+
+func ExampleORSet() {
+	a := NewORSet[string]("a")
+	b := NewORSet[string]("b")
+	a.Add("x")
+	b.Merge(a)
+
+	// Concurrently, a removes x while b adds it again.
+	a.Remove("x")
+	b.Add("x")
+
+	a.Merge(b)
+	b.Merge(a)
+	fmt.Println(a.Contains("x"), b.Contains("x"))
+	// Output: true true
+}
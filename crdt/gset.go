@@ -0,0 +1,115 @@
+// Package crdt implements convergent replicated set types (CRDTs).
+//
+// Every type offers Merge, which is commutative, associative and
+// idempotent, so replicas converge no matter how often or in which order
+// they exchange state. Delta returns only the changes made since the
+// previous call, as a value of the same type that can be passed to Merge on
+// other replicas. All types are safe for concurrent use and serialize to
+// JSON.
+package crdt
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// GSet is a grow-only set: elements can be added but never removed.
+type GSet[T comparable] struct {
+	elements *set.Set[T]
+	delta    *set.Set[T]
+	mu       sync.RWMutex
+}
+
+// NewGSet creates and returns a new, empty GSet.
+func NewGSet[T comparable]() *GSet[T] {
+	return &GSet[T]{
+		elements: set.New[T](),
+		delta:    set.New[T](),
+	}
+}
+
+// Add inserts an element into the set.
+func (g *GSet[T]) Add(elem T) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.elements.Contains(elem) {
+		g.elements.Add(elem)
+		g.delta.Add(elem)
+	}
+}
+
+// Contains checks if an element is in the set.
+func (g *GSet[T]) Contains(elem T) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.elements.Contains(elem)
+}
+
+// Size returns the number of elements in the set.
+func (g *GSet[T]) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.elements.Size()
+}
+
+// Elements returns a copy of the elements of the set.
+func (g *GSet[T]) Elements() *set.Set[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.elements.Copy()
+}
+
+// Merge adds every element of other into g.
+func (g *GSet[T]) Merge(other *GSet[T]) {
+	theirs := other.Elements()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	theirs.ForEach(func(elem T) {
+		if !g.elements.Contains(elem) {
+			g.elements.Add(elem)
+			g.delta.Add(elem)
+		}
+	})
+}
+
+// Delta returns the elements added or merged since the previous call and
+// resets the pending delta.
+func (g *GSet[T]) Delta() *GSet[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delta := &GSet[T]{elements: g.delta, delta: set.New[T]()}
+	g.delta = set.New[T]()
+	return delta
+}
+
+// Equal checks if both replicas hold the same state.
+func (g *GSet[T]) Equal(other *GSet[T]) bool {
+	return g.Elements().Equal(other.Elements())
+}
+
+type gsetJSON[T comparable] struct {
+	Elements []T `json:"elements"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g *GSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gsetJSON[T]{Elements: g.Elements().ToSlice()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the state of g.
+func (g *GSet[T]) UnmarshalJSON(data []byte) error {
+	var decoded gsetJSON[T]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	elements := set.New[T]()
+	for _, elem := range decoded.Elements {
+		elements.Add(elem)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.elements, g.delta = elements, set.New[T]()
+	return nil
+}
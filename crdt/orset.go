@@ -0,0 +1,259 @@
+package crdt
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// Tag uniquely identifies one addition: the replica that made it and that
+// replica's counter at the time.
+type Tag struct {
+	Replica string `json:"replica"`
+	Counter uint64 `json:"counter"`
+}
+
+// ORSet is an observed-remove set. Every addition carries a unique tag,
+// and a removal only deletes the tags its replica has observed, so an add
+// concurrent with a remove wins and elements can be re-added freely.
+type ORSet[T comparable] struct {
+	replica    string
+	counter    uint64
+	entries    map[T]*set.Set[Tag]
+	owners     map[Tag]T // the element each live tag in entries belongs to
+	tombstones *set.Set[Tag]
+	// The delta holds the additions and tombstones since the last Delta.
+	deltaEntries    map[T]*set.Set[Tag]
+	deltaTombstones *set.Set[Tag]
+	mu              sync.RWMutex
+}
+
+// NewORSet creates an empty ORSet for the replica with the given ID. Each
+// replica must use a distinct ID.
+func NewORSet[T comparable](replica string) *ORSet[T] {
+	return &ORSet[T]{
+		replica:         replica,
+		entries:         make(map[T]*set.Set[Tag]),
+		owners:          make(map[Tag]T),
+		tombstones:      set.New[Tag](),
+		deltaEntries:    make(map[T]*set.Set[Tag]),
+		deltaTombstones: set.New[Tag](),
+	}
+}
+
+// Replica returns the replica ID of the set.
+func (o *ORSet[T]) Replica() string {
+	return o.replica
+}
+
+// addTag records a live tag for elem, in the state and in the delta.
+func (o *ORSet[T]) addTag(elem T, tag Tag) {
+	if o.tombstones.Contains(tag) {
+		return
+	}
+	for _, entries := range []map[T]*set.Set[Tag]{o.entries, o.deltaEntries} {
+		tags, ok := entries[elem]
+		if !ok {
+			tags = set.New[Tag]()
+			entries[elem] = tags
+		}
+		tags.Add(tag)
+	}
+	o.owners[tag] = elem
+	if tag.Replica == o.replica && tag.Counter > o.counter {
+		o.counter = tag.Counter
+	}
+}
+
+// removeTag tombstones a tag, in the state and in the delta. The caller
+// removes the tag from its element's entry.
+func (o *ORSet[T]) removeTag(tag Tag) {
+	o.tombstones.Add(tag)
+	o.deltaTombstones.Add(tag)
+	delete(o.owners, tag)
+}
+
+// Add inserts an element into the set under a fresh tag.
+func (o *ORSet[T]) Add(elem T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.addTag(elem, Tag{Replica: o.replica, Counter: o.counter + 1})
+}
+
+// Remove deletes an element by tombstoning every tag observed for it. It
+// reports whether the element was present.
+func (o *ORSet[T]) Remove(elem T) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	tags, ok := o.entries[elem]
+	if !ok {
+		return false
+	}
+	tags.ForEach(o.removeTag)
+	delete(o.entries, elem)
+	return true
+}
+
+// Contains checks if an element is in the set.
+func (o *ORSet[T]) Contains(elem T) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	_, ok := o.entries[elem]
+	return ok
+}
+
+// Elements returns the elements currently in the set.
+func (o *ORSet[T]) Elements() *set.Set[T] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	result := set.New[T]()
+	for elem := range o.entries {
+		result.Add(elem)
+	}
+	return result
+}
+
+// Size returns the number of elements currently in the set.
+func (o *ORSet[T]) Size() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.entries)
+}
+
+// orState is a snapshot of an ORSet, also used as its JSON form.
+type orState[T comparable] struct {
+	Replica    string       `json:"replica"`
+	Counter    uint64       `json:"counter"`
+	Entries    []orEntry[T] `json:"entries"`
+	Tombstones []Tag        `json:"tombstones"`
+}
+
+type orEntry[T comparable] struct {
+	Element T     `json:"element"`
+	Tags    []Tag `json:"tags"`
+}
+
+func snapshot[T comparable](entries map[T]*set.Set[Tag], tombstones *set.Set[Tag]) ([]orEntry[T], []Tag) {
+	result := make([]orEntry[T], 0, len(entries))
+	for elem, tags := range entries {
+		result = append(result, orEntry[T]{Element: elem, Tags: tags.ToSlice()})
+	}
+	return result, tombstones.ToSlice()
+}
+
+func (o *ORSet[T]) state() orState[T] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	entries, tombstones := snapshot(o.entries, o.tombstones)
+	return orState[T]{Replica: o.replica, Counter: o.counter, Entries: entries, Tombstones: tombstones}
+}
+
+// apply merges a snapshot into o. Tombstones go first so that tags removed
+// elsewhere are dropped from the local entries, found through owners.
+func (o *ORSet[T]) apply(state orState[T]) {
+	for _, tag := range state.Tombstones {
+		if o.tombstones.Contains(tag) {
+			continue
+		}
+		if elem, ok := o.owners[tag]; ok {
+			tags := o.entries[elem]
+			tags.Remove(tag)
+			if tags.Size() == 0 {
+				delete(o.entries, elem)
+			}
+		}
+		o.removeTag(tag)
+	}
+	for _, entry := range state.Entries {
+		for _, tag := range entry.Tags {
+			o.addTag(entry.Element, tag)
+		}
+	}
+}
+
+// Merge folds the additions and removals of other into o.
+func (o *ORSet[T]) Merge(other *ORSet[T]) {
+	state := other.state()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.apply(state)
+}
+
+// Delta returns the additions and removals since the previous call, as an
+// ORSet to pass to Merge on other replicas, and resets the pending delta.
+func (o *ORSet[T]) Delta() *ORSet[T] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delta := &ORSet[T]{
+		replica:         o.replica,
+		counter:         o.counter,
+		entries:         o.deltaEntries,
+		owners:          make(map[Tag]T),
+		tombstones:      o.deltaTombstones,
+		deltaEntries:    make(map[T]*set.Set[Tag]),
+		deltaTombstones: set.New[Tag](),
+	}
+	for elem, tags := range delta.entries {
+		tags.ForEach(func(tag Tag) { delta.owners[tag] = elem })
+	}
+	o.deltaEntries = make(map[T]*set.Set[Tag])
+	o.deltaTombstones = set.New[Tag]()
+	return delta
+}
+
+// Equal checks if both replicas hold the same elements, tags and
+// tombstones.
+func (o *ORSet[T]) Equal(other *ORSet[T]) bool {
+	mine, theirs := o.state(), other.state()
+	if len(mine.Entries) != len(theirs.Entries) || len(mine.Tombstones) != len(theirs.Tombstones) {
+		return false
+	}
+	tombstones := set.New[Tag]()
+	for _, tag := range theirs.Tombstones {
+		tombstones.Add(tag)
+	}
+	for _, tag := range mine.Tombstones {
+		if !tombstones.Contains(tag) {
+			return false
+		}
+	}
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	for _, entry := range mine.Entries {
+		tags, ok := other.entries[entry.Element]
+		if !ok || tags.Size() != len(entry.Tags) {
+			return false
+		}
+		for _, tag := range entry.Tags {
+			if !tags.Contains(tag) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *ORSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.state())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the state of o
+// including its replica ID.
+func (o *ORSet[T]) UnmarshalJSON(data []byte) error {
+	var state orState[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.replica, o.counter = state.Replica, state.Counter
+	o.entries = make(map[T]*set.Set[Tag])
+	o.owners = make(map[Tag]T)
+	o.tombstones = set.New[Tag]()
+	o.apply(state)
+	o.deltaEntries = make(map[T]*set.Set[Tag])
+	o.deltaTombstones = set.New[Tag]()
+	return nil
+}
@@ -0,0 +1,121 @@
+package crdt
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// TwoPSet is a two-phase set: a grow-only set of additions paired with a
+// grow-only set of removals. Once removed, an element can never be added
+// back.
+type TwoPSet[T comparable] struct {
+	added   *GSet[T]
+	removed *GSet[T]
+	mu      sync.RWMutex
+}
+
+// NewTwoPSet creates and returns a new, empty TwoPSet.
+func NewTwoPSet[T comparable]() *TwoPSet[T] {
+	return &TwoPSet[T]{
+		added:   NewGSet[T](),
+		removed: NewGSet[T](),
+	}
+}
+
+// Add inserts an element into the set. It has no effect if the element was
+// ever removed.
+func (s *TwoPSet[T]) Add(elem T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.added.Add(elem)
+}
+
+// Remove deletes an element from the set permanently. It reports false,
+// and does nothing, if the element is not currently in the set.
+func (s *TwoPSet[T]) Remove(elem T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.added.Contains(elem) || s.removed.Contains(elem) {
+		return false
+	}
+	s.removed.Add(elem)
+	return true
+}
+
+// Contains checks if an element is in the set.
+func (s *TwoPSet[T]) Contains(elem T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.added.Contains(elem) && !s.removed.Contains(elem)
+}
+
+// Elements returns the elements currently in the set.
+func (s *TwoPSet[T]) Elements() *set.Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.added.Elements().Difference(s.removed.Elements())
+}
+
+// Size returns the number of elements currently in the set.
+func (s *TwoPSet[T]) Size() int {
+	return s.Elements().Size()
+}
+
+// parts returns the underlying grow-only sets.
+func (s *TwoPSet[T]) parts() (*GSet[T], *GSet[T]) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.added, s.removed
+}
+
+// Merge folds the additions and removals of other into s.
+func (s *TwoPSet[T]) Merge(other *TwoPSet[T]) {
+	added, removed := other.parts()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.added.Merge(added)
+	s.removed.Merge(removed)
+}
+
+// Delta returns the additions and removals since the previous call and
+// resets the pending delta.
+func (s *TwoPSet[T]) Delta() *TwoPSet[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &TwoPSet[T]{
+		added:   s.added.Delta(),
+		removed: s.removed.Delta(),
+	}
+}
+
+// Equal checks if both replicas hold the same state.
+func (s *TwoPSet[T]) Equal(other *TwoPSet[T]) bool {
+	added, removed := s.parts()
+	otherAdded, otherRemoved := other.parts()
+	return added.Equal(otherAdded) && removed.Equal(otherRemoved)
+}
+
+type twoPSetJSON[T comparable] struct {
+	Added   *GSet[T] `json:"added"`
+	Removed *GSet[T] `json:"removed"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *TwoPSet[T]) MarshalJSON() ([]byte, error) {
+	added, removed := s.parts()
+	return json.Marshal(twoPSetJSON[T]{Added: added, Removed: removed})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the state of s.
+func (s *TwoPSet[T]) UnmarshalJSON(data []byte) error {
+	decoded := twoPSetJSON[T]{Added: NewGSet[T](), Removed: NewGSet[T]()}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.added, s.removed = decoded.Added, decoded.Removed
+	return nil
+}
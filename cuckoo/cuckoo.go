@@ -0,0 +1,197 @@
+// Package cuckoo implements a thread-safe cuckoo filter: a probabilistic
+// set that, unlike a Bloom filter, supports deletion.
+package cuckoo
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+const (
+	// bucketSize is the number of fingerprints per bucket.
+	bucketSize = 4
+	// maxKicks bounds the relocation chain of a single insertion.
+	maxKicks = 500
+	// DefaultLoadFactor is the target occupancy used by New.
+	DefaultLoadFactor = 0.95
+)
+
+// ErrFull is returned by Add when no slot can be freed for the element.
+// The filter is left unchanged.
+var ErrFull = errors.New("cuckoo: filter is full")
+
+type bucket [bucketSize]uint16
+
+// Filter is a thread-safe cuckoo filter storing 16-bit fingerprints in
+// buckets of four. Its false-positive rate is about 8/65536.
+type Filter[T any] struct {
+	buckets []bucket
+	mask    uint64
+	count   int
+	hash    hasher.Func[T]
+	mu      sync.RWMutex
+}
+
+// New creates a filter able to hold capacity elements at the default load
+// factor, using the default hasher for T.
+func New[T comparable](capacity uint64) *Filter[T] {
+	return NewWithHasher(capacity, DefaultLoadFactor, hasher.For[T]())
+}
+
+// NewWithHasher creates a filter able to hold capacity elements while
+// keeping its occupancy at or below loadFactor, hashing elements with h.
+func NewWithHasher[T any](capacity uint64, loadFactor float64, h hasher.Func[T]) *Filter[T] {
+	if loadFactor <= 0 || loadFactor > 1 {
+		loadFactor = DefaultLoadFactor
+	}
+	needed := uint64(math.Ceil(float64(capacity) / (bucketSize * loadFactor)))
+	numBuckets := uint64(1)
+	for numBuckets < needed {
+		numBuckets <<= 1
+	}
+	return &Filter[T]{
+		buckets: make([]bucket, numBuckets),
+		mask:    numBuckets - 1,
+		hash:    h,
+	}
+}
+
+// fingerprint returns the element's non-zero fingerprint and first bucket.
+func (f *Filter[T]) fingerprint(elem T) (uint16, uint64) {
+	h := f.hash(elem)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & f.mask
+}
+
+// altIndex returns the other candidate bucket for a fingerprint. Applying
+// it twice yields the original index.
+func (f *Filter[T]) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ hasher.Uint64(uint64(fp))) & f.mask
+}
+
+func (b *bucket) insert(fp uint16) bool {
+	for i, v := range b {
+		if v == 0 {
+			b[i] = fp
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) remove(fp uint16) bool {
+	for i, v := range b {
+		if v == fp {
+			b[i] = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) contains(fp uint16) bool {
+	for _, v := range b {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// Add inserts an element into the filter. It returns ErrFull if the
+// element could not be placed after evicting up to maxKicks fingerprints.
+// Adding the same element twice stores two fingerprints.
+func (f *Filter[T]) Add(elem T) error {
+	fp, i1 := f.fingerprint(elem)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i2 := f.altIndex(i1, fp)
+	if f.buckets[i1].insert(fp) || f.buckets[i2].insert(fp) {
+		f.count++
+		return nil
+	}
+
+	// Relocate existing fingerprints, remembering each swap so a failed
+	// insertion can be rolled back without losing a stored element.
+	type swap struct {
+		index uint64
+		slot  int
+	}
+	var path []swap
+	i := i1
+	if hasher.Uint64(uint64(fp))&1 == 1 {
+		i = i2
+	}
+	for kick := 0; kick < maxKicks; kick++ {
+		slot := int(hasher.Mix(uint64(fp)^uint64(kick)) % bucketSize)
+		fp, f.buckets[i][slot] = f.buckets[i][slot], fp
+		path = append(path, swap{index: i, slot: slot})
+		i = f.altIndex(i, fp)
+		if f.buckets[i].insert(fp) {
+			f.count++
+			return nil
+		}
+	}
+	for k := len(path) - 1; k >= 0; k-- {
+		s := path[k]
+		fp, f.buckets[s.index][s.slot] = f.buckets[s.index][s.slot], fp
+	}
+	return ErrFull
+}
+
+// Remove deletes one occurrence of an element from the filter and reports
+// whether a matching fingerprint was found. Removing an element that was
+// never added may delete another element sharing its fingerprint.
+func (f *Filter[T]) Remove(elem T) bool {
+	fp, i1 := f.fingerprint(elem)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.buckets[i1].remove(fp) || f.buckets[f.altIndex(i1, fp)].remove(fp) {
+		f.count--
+		return true
+	}
+	return false
+}
+
+// Contains reports whether an element may be in the filter. A false result
+// means the element is definitely absent.
+func (f *Filter[T]) Contains(elem T) bool {
+	fp, i1 := f.fingerprint(elem)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.buckets[i1].contains(fp) || f.buckets[f.altIndex(i1, fp)].contains(fp)
+}
+
+// Size returns the number of fingerprints stored in the filter.
+func (f *Filter[T]) Size() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.count
+}
+
+// Capacity returns the total number of fingerprint slots.
+func (f *Filter[T]) Capacity() int {
+	return len(f.buckets) * bucketSize
+}
+
+// LoadFactor returns the fraction of slots in use.
+func (f *Filter[T]) LoadFactor() float64 {
+	return float64(f.Size()) / float64(f.Capacity())
+}
+
+// Clear removes every element from the filter.
+func (f *Filter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.buckets {
+		f.buckets[i] = bucket{}
+	}
+	f.count = 0
+}
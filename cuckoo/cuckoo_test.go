@@ -0,0 +1,103 @@
+package cuckoo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+func TestAddContainsRemove(t *testing.T) {
+	f := New[string](1000)
+	if err := f.Add("a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !f.Contains("a") {
+		t.Errorf("Expected filter to contain %q", "a")
+	}
+	if f.Contains("b") {
+		t.Errorf("Expected filter to not contain %q", "b")
+	}
+	if !f.Remove("a") {
+		t.Errorf("Expected Remove to find %q", "a")
+	}
+	if f.Contains("a") || f.Size() != 0 {
+		t.Errorf("Expected filter to be empty after Remove")
+	}
+	if f.Remove("a") {
+		t.Errorf("Expected second Remove to fail")
+	}
+}
+
+func TestLoadFactor(t *testing.T) {
+	const n = 10000
+	f := NewWithHasher(n, 0.9, hasher.For[int]())
+	for i := 0; i < n; i++ {
+		if err := f.Add(i); err != nil {
+			t.Fatalf("Unexpected error at %d (load %.2f): %v", i, f.LoadFactor(), err)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !f.Contains(i) {
+			t.Fatalf("Expected filter to contain %d", i)
+		}
+	}
+	falsePositives := 0
+	for i := n; i < 11*n; i++ {
+		if f.Contains(i) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / (10 * n); rate > 0.001 {
+		t.Errorf("Expected false-positive rate below 0.001, got %v", rate)
+	}
+}
+
+func TestFull(t *testing.T) {
+	f := New[int](64)
+	var err error
+	added := 0
+	for ; added < 10*f.Capacity(); added++ {
+		if err = f.Add(added); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrFull) {
+		t.Fatalf("Expected ErrFull, got %v", err)
+	}
+	if f.Size() != added {
+		t.Errorf("Expected size %d after failed insert, got %d", added, f.Size())
+	}
+	for i := 0; i < added; i++ {
+		if !f.Contains(i) {
+			t.Fatalf("Expected failed insert to keep %d", i)
+		}
+	}
+	f.Clear()
+	if f.Size() != 0 || f.Contains(0) {
+		t.Errorf("Expected filter to be empty after Clear")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	f := New[int](8000)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				f.Add(g*1000 + i)
+				f.Contains(i)
+			}
+			for i := 0; i < 500; i++ {
+				f.Remove(g*1000 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if f.Size() != 4000 {
+		t.Errorf("Expected size 4000, got %d", f.Size())
+	}
+}
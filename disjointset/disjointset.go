@@ -0,0 +1,231 @@
+// Package disjointset implements a thread-safe union-find structure for
+// grouping elements into disjoint components.
+package disjointset
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+var (
+	// ErrNoRollback is returned by Rollback on a structure created without
+	// rollback support.
+	ErrNoRollback = errors.New("disjointset: rollback is not enabled")
+	// ErrInvalidSnapshot is returned by Rollback for a snapshot that was
+	// already rolled back past or never taken.
+	ErrInvalidSnapshot = errors.New("disjointset: invalid snapshot")
+)
+
+// change records one mutation so it can be undone. A negative child marks
+// the addition of a new element.
+type change struct {
+	child, root int
+	rootRank    int
+}
+
+// DisjointSet is a thread-safe union-find structure with union by rank.
+// By default Find also compresses paths, making operations effectively
+// constant time. In rollback mode path compression is disabled so that
+// every change can be undone, and operations take O(log n).
+type DisjointSet[T comparable] struct {
+	index    map[T]int
+	elems    []T
+	parent   []int
+	rank     []int
+	size     []int
+	count    int
+	rollback bool
+	history  []change
+	mu       sync.Mutex
+}
+
+// New creates and returns a new, empty DisjointSet.
+func New[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{index: make(map[T]int)}
+}
+
+// NewWithRollback creates an empty DisjointSet whose changes can be undone
+// with Snapshot and Rollback, as needed by offline algorithms.
+func NewWithRollback[T comparable]() *DisjointSet[T] {
+	d := New[T]()
+	d.rollback = true
+	return d
+}
+
+// add returns the index of elem, inserting it as a singleton if needed.
+func (d *DisjointSet[T]) add(elem T) int {
+	if i, ok := d.index[elem]; ok {
+		return i
+	}
+	i := len(d.elems)
+	d.index[elem] = i
+	d.elems = append(d.elems, elem)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	if d.rollback {
+		d.history = append(d.history, change{child: -1})
+	}
+	return i
+}
+
+// find returns the root index of i.
+func (d *DisjointSet[T]) find(i int) int {
+	root := i
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	if !d.rollback {
+		for d.parent[i] != root {
+			d.parent[i], i = root, d.parent[i]
+		}
+	}
+	return root
+}
+
+// Add inserts an element as a singleton component if it is not present.
+func (d *DisjointSet[T]) Add(elem T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.add(elem)
+}
+
+// Contains checks if an element has been added.
+func (d *DisjointSet[T]) Contains(elem T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.index[elem]
+	return ok
+}
+
+// Union merges the components of a and b, adding either element if it is
+// not present. It reports whether two distinct components were merged.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ra, rb := d.find(d.add(a)), d.find(d.add(b))
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	if d.rollback {
+		d.history = append(d.history, change{child: rb, root: ra, rootRank: d.rank[ra]})
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.count--
+	return true
+}
+
+// Find returns the representative of the component containing elem, or
+// false if elem has not been added.
+func (d *DisjointSet[T]) Find(elem T) (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, ok := d.index[elem]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return d.elems[d.find(i)], true
+}
+
+// Connected reports whether a and b are in the same component.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, okA := d.index[a]
+	j, okB := d.index[b]
+	return okA && okB && d.find(i) == d.find(j)
+}
+
+// ComponentSize returns the number of elements in the component containing
+// elem, or 0 if elem has not been added.
+func (d *DisjointSet[T]) ComponentSize(elem T) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, ok := d.index[elem]
+	if !ok {
+		return 0
+	}
+	return d.size[d.find(i)]
+}
+
+// Size returns the number of elements.
+func (d *DisjointSet[T]) Size() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.elems)
+}
+
+// Count returns the number of components.
+func (d *DisjointSet[T]) Count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
+
+// Components returns every component as a separate set.
+func (d *DisjointSet[T]) Components() []*set.Set[T] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	byRoot := make(map[int]*set.Set[T], d.count)
+	components := make([]*set.Set[T], 0, d.count)
+	for i, elem := range d.elems {
+		root := d.find(i)
+		component, ok := byRoot[root]
+		if !ok {
+			component = set.New[T]()
+			byRoot[root] = component
+			components = append(components, component)
+		}
+		component.Add(elem)
+	}
+	return components
+}
+
+// Snapshot returns a marker of the current state to pass to Rollback.
+func (d *DisjointSet[T]) Snapshot() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.history)
+}
+
+// Rollback undoes every Add and Union made since the snapshot was taken.
+func (d *DisjointSet[T]) Rollback(snapshot int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.rollback {
+		return ErrNoRollback
+	}
+	if snapshot < 0 || snapshot > len(d.history) {
+		return ErrInvalidSnapshot
+	}
+	for len(d.history) > snapshot {
+		c := d.history[len(d.history)-1]
+		d.history = d.history[:len(d.history)-1]
+		if c.child < 0 {
+			last := len(d.elems) - 1
+			delete(d.index, d.elems[last])
+			d.elems = d.elems[:last]
+			d.parent = d.parent[:last]
+			d.rank = d.rank[:last]
+			d.size = d.size[:last]
+			d.count--
+			continue
+		}
+		d.parent[c.child] = c.child
+		d.size[c.root] -= d.size[c.child]
+		d.rank[c.root] = c.rootRank
+		d.count++
+	}
+	return nil
+}
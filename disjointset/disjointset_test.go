@@ -0,0 +1,139 @@
+package disjointset
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	d := New[string]()
+	if !d.Union("a", "b") {
+		t.Errorf("Expected first Union to merge")
+	}
+	d.Union("c", "d")
+	if d.Union("b", "a") {
+		t.Errorf("Expected Union of connected elements to report false")
+	}
+	if !d.Connected("a", "b") || d.Connected("a", "c") {
+		t.Errorf("Connected returned an unexpected result")
+	}
+	d.Union("b", "d")
+	if !d.Connected("a", "c") {
+		t.Errorf("Expected a and c to be connected")
+	}
+	if size := d.ComponentSize("c"); size != 4 {
+		t.Errorf("Expected component size 4, got %d", size)
+	}
+	ra, _ := d.Find("a")
+	rd, _ := d.Find("d")
+	if ra != rd {
+		t.Errorf("Expected a and d to share a representative, got %q and %q", ra, rd)
+	}
+	if _, ok := d.Find("z"); ok {
+		t.Errorf("Expected Find of an unknown element to fail")
+	}
+	if d.ComponentSize("z") != 0 || d.Connected("z", "z") {
+		t.Errorf("Expected unknown elements to have no component")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 10; i++ {
+		d.Add(i)
+	}
+	for i := 0; i+2 < 10; i++ {
+		d.Union(i, i+2)
+	}
+	if d.Count() != 2 {
+		t.Fatalf("Expected 2 components, got %d", d.Count())
+	}
+	components := d.Components()
+	sizes := []int{}
+	for _, c := range components {
+		sizes = append(sizes, c.Size())
+		first := c.ToSlice()[0]
+		c.ForEach(func(v int) {
+			if v%2 != first%2 {
+				t.Errorf("Component mixes parities: %v", c.ToSlice())
+			}
+		})
+	}
+	sort.Ints(sizes)
+	if len(sizes) != 2 || sizes[0] != 5 || sizes[1] != 5 {
+		t.Errorf("Expected two components of 5, got %v", sizes)
+	}
+}
+
+func TestAgainstNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := New[int]()
+	label := make([]int, 200)
+	for i := range label {
+		label[i] = i
+		d.Add(i)
+	}
+	for step := 0; step < 300; step++ {
+		a, b := rng.Intn(200), rng.Intn(200)
+		d.Union(a, b)
+		from, to := label[b], label[a]
+		for i := range label {
+			if label[i] == from {
+				label[i] = to
+			}
+		}
+		x, y := rng.Intn(200), rng.Intn(200)
+		if d.Connected(x, y) != (label[x] == label[y]) {
+			t.Fatalf("step %d: Connected(%d, %d) disagrees with naive labels", step, x, y)
+		}
+	}
+}
+
+func TestRollback(t *testing.T) {
+	d := NewWithRollback[int]()
+	d.Union(1, 2)
+	snap := d.Snapshot()
+	d.Union(2, 3)
+	d.Union(4, 5)
+	d.Union(1, 5)
+	if !d.Connected(3, 4) || d.ComponentSize(1) != 5 {
+		t.Fatalf("Expected 1..5 to be connected")
+	}
+	if err := d.Rollback(snap); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !d.Connected(1, 2) || d.Connected(2, 3) || d.Contains(4) {
+		t.Errorf("Rollback did not restore the snapshot state")
+	}
+	if d.Size() != 2 || d.Count() != 1 || d.ComponentSize(1) != 2 {
+		t.Errorf("Expected 2 elements in 1 component, got %d in %d", d.Size(), d.Count())
+	}
+	if err := d.Rollback(snap + 1); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+	if err := New[int]().Rollback(0); !errors.Is(err, ErrNoRollback) {
+		t.Errorf("Expected ErrNoRollback, got %v", err)
+	}
+}
+
+func TestConcurrentUnion(t *testing.T) {
+	d := New[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				d.Union(i, i+1)
+				d.Connected(0, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if d.Count() != 1 || d.ComponentSize(0) != 1001 {
+		t.Errorf("Expected a single component of 1001, got %d components", d.Count())
+	}
+}
@@ -0,0 +1,107 @@
+// Package hasher provides deterministic 64-bit hash functions for the
+// probabilistic data structures in this module.
+//
+// The hashes are stable across processes and platforms for strings, byte
+// slices, booleans and numeric types (including named types built on
+// them), so filters and sketches built from them can be serialized and
+// merged by other services.
+package hasher
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Func returns a 64-bit hash of a value.
+type Func[T any] func(T) uint64
+
+// For returns the default hash function for a comparable type.
+//
+// Values that are neither strings, booleans nor numbers are hashed through
+// their %#v representation, which is deterministic for structs and arrays
+// of such values but includes addresses for pointers and channels.
+func For[T comparable]() Func[T] {
+	return func(v T) uint64 {
+		return Any(v)
+	}
+}
+
+// Any hashes a value with the rules described on For.
+func Any(v any) uint64 {
+	switch v := v.(type) {
+	case string:
+		return String(v)
+	case []byte:
+		return Bytes(v)
+	case int:
+		return Uint64(uint64(v))
+	case int64:
+		return Uint64(uint64(v))
+	case uint64:
+		return Uint64(v)
+	case uint32:
+		return Uint64(uint64(v))
+	case int32:
+		return Uint64(uint64(v))
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return String(rv.String())
+	case reflect.Bool:
+		if rv.Bool() {
+			return Uint64(1)
+		}
+		return Uint64(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Uint64(uint64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Uint64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return Float64(rv.Float())
+	}
+	return String(fmt.Sprintf("%#v", v))
+}
+
+// String hashes a string with FNV-1a followed by a 64-bit finalizer.
+func String(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return Mix(h.Sum64())
+}
+
+// Bytes hashes a byte slice; it agrees with String for the same bytes.
+func Bytes(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return Mix(h.Sum64())
+}
+
+// Uint64 hashes an integer.
+func Uint64(x uint64) uint64 {
+	return Mix(x)
+}
+
+// Float64 hashes a float. Positive and negative zero hash alike, matching
+// Go's == semantics.
+func Float64(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return Mix(math.Float64bits(f))
+}
+
+// Mix is the splitmix64 finalizer. It spreads every input bit over the
+// whole output and is also used to derive independent hashes from one
+// value, e.g. Mix(h ^ seed).
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,50 @@
+package hasher
+
+import (
+	"math"
+	"testing"
+)
+
+type userID string
+
+type point struct {
+	X, Y int
+}
+
+func TestForIsDeterministic(t *testing.T) {
+	if For[string]()("hello") != String("hello") {
+		t.Errorf("Expected For[string] to match String")
+	}
+	if For[userID]()("hello") != String("hello") {
+		t.Errorf("Expected named string types to hash like string")
+	}
+	if For[int8]()(5) != Uint64(5) || For[uint16]()(5) != Uint64(5) {
+		t.Errorf("Expected small integer types to hash like uint64")
+	}
+	if Bytes([]byte("abc")) != String("abc") {
+		t.Errorf("Expected Bytes and String to agree")
+	}
+	h := For[point]()
+	if h(point{1, 2}) != h(point{1, 2}) {
+		t.Errorf("Expected equal structs to hash alike")
+	}
+	if h(point{1, 2}) == h(point{2, 1}) {
+		t.Errorf("Expected different structs to hash differently")
+	}
+}
+
+func TestFloat64Zero(t *testing.T) {
+	if Float64(0) != Float64(math.Copysign(0, -1)) {
+		t.Errorf("Expected +0 and -0 to hash alike")
+	}
+}
+
+func TestMixSpreadsBits(t *testing.T) {
+	seen := map[uint64]bool{}
+	for i := uint64(0); i < 1000; i++ {
+		seen[Mix(i)>>54] = true
+	}
+	if len(seen) < 500 {
+		t.Errorf("Expected sequential inputs to spread over the top bits, got %d buckets", len(seen))
+	}
+}
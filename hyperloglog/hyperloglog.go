@@ -0,0 +1,275 @@
+// Package hyperloglog implements a thread-safe HyperLogLog++ cardinality
+// estimator.
+//
+// Small cardinalities are tracked in a sparse list at precision 25, which
+// is nearly exact, and the sketch switches to 2^p dense registers once the
+// list would outgrow them. Dense estimates use Ertl's improved estimator,
+// which removes the small-range bias that HyperLogLog++ otherwise corrects
+// with empirical tables.
+package hyperloglog
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+const (
+	// MinPrecision and MaxPrecision bound the number of index bits.
+	MinPrecision = 4
+	MaxPrecision = 18
+	// sparsePrecision is the number of index bits used in sparse mode.
+	sparsePrecision = 25
+	// tmpSize is the number of unsorted sparse entries buffered between
+	// merges into the sorted list.
+	tmpSize = 256
+)
+
+var (
+	// ErrPrecision is returned for a precision outside
+	// [MinPrecision, MaxPrecision].
+	ErrPrecision = errors.New("hyperloglog: precision out of range")
+	// ErrIncompatible is returned when merging sketches of different
+	// precisions.
+	ErrIncompatible = errors.New("hyperloglog: sketches have different precisions")
+	// ErrInvalidFormat is returned when decoding malformed data.
+	ErrInvalidFormat = errors.New("hyperloglog: invalid serialized sketch")
+)
+
+// HyperLogLog estimates the number of distinct elements added to it using
+// a fixed amount of memory. The standard error is about 1.04/sqrt(2^p).
+type HyperLogLog[T any] struct {
+	p         uint8
+	registers []uint8  // dense registers, nil in sparse mode
+	sparse    []uint32 // sorted encoded entries, see encodeSparse
+	tmp       []uint32 // unsorted entries awaiting a merge into sparse
+	hash      hasher.Func[T]
+	mu        sync.RWMutex
+}
+
+// New creates a sketch with 2^precision registers, using the default
+// hasher for T.
+func New[T comparable](precision uint8) (*HyperLogLog[T], error) {
+	return NewWithHasher(precision, hasher.For[T]())
+}
+
+// NewWithHasher creates a sketch with 2^precision registers, hashing
+// elements with h.
+func NewWithHasher[T any](precision uint8, h hasher.Func[T]) (*HyperLogLog[T], error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrPrecision
+	}
+	return &HyperLogLog[T]{p: precision, hash: h}, nil
+}
+
+// Precision returns the number of index bits of the sketch.
+func (h *HyperLogLog[T]) Precision() uint8 {
+	return h.p
+}
+
+// Add records an element.
+func (h *HyperLogLog[T]) Add(elem T) {
+	h.AddHash(h.hash(elem))
+}
+
+// AddHash records a precomputed 64-bit hash.
+func (h *HyperLogLog[T]) AddHash(x uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.registers != nil {
+		idx, rho := denseEntry(x, h.p)
+		if rho > h.registers[idx] {
+			h.registers[idx] = rho
+		}
+		return
+	}
+	h.tmp = append(h.tmp, encodeSparse(x))
+	if len(h.tmp) >= tmpSize {
+		h.mergeTmp()
+	}
+}
+
+// Count returns the estimated number of distinct elements added.
+func (h *HyperLogLog[T]) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.registers == nil {
+		h.mergeTmp()
+		// Linear counting over 2^25 sparse registers is close to exact
+		// until the sketch converts to dense mode.
+		m := float64(uint64(1) << sparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+	return estimate(h.registers, h.p)
+}
+
+// Merge adds every element recorded by other into h. Both sketches must
+// have the same precision.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.p != other.p {
+		return ErrIncompatible
+	}
+	// Snapshot other first so the two locks are never held together.
+	other.mu.Lock()
+	other.mergeTmp()
+	registers := append([]uint8(nil), other.registers...)
+	sparse := append([]uint32(nil), other.sparse...)
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if registers == nil {
+		if h.registers != nil {
+			for _, e := range sparse {
+				h.foldSparse(e)
+			}
+			return nil
+		}
+		h.tmp = append(h.tmp, sparse...)
+		h.mergeTmp()
+		return nil
+	}
+	h.toDense()
+	for i, rho := range registers {
+		if rho > h.registers[i] {
+			h.registers[i] = rho
+		}
+	}
+	return nil
+}
+
+// Clear resets the sketch to its empty, sparse state.
+func (h *HyperLogLog[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.registers, h.sparse, h.tmp = nil, nil, nil
+}
+
+// IsSparse reports whether the sketch is still in its sparse representation.
+func (h *HyperLogLog[T]) IsSparse() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.registers == nil
+}
+
+// denseEntry returns the register index and rank of a hash at precision p.
+func denseEntry(x uint64, p uint8) (uint32, uint8) {
+	idx := uint32(x >> (64 - p))
+	w := x<<p | 1<<(p-1)
+	return idx, uint8(bits.LeadingZeros64(w) + 1)
+}
+
+// encodeSparse packs the precision-25 index and rank of a hash as
+// index<<6 | rank.
+func encodeSparse(x uint64) uint32 {
+	idx, rho := denseEntry(x, sparsePrecision)
+	return idx<<6 | uint32(rho)
+}
+
+// mergeTmp folds the buffered entries into the sorted sparse list, keeping
+// the highest rank per index, and converts to dense mode once the list is
+// larger than the dense registers would be.
+func (h *HyperLogLog[T]) mergeTmp() {
+	if h.registers != nil || len(h.tmp) == 0 {
+		return
+	}
+	entries := append(h.sparse, h.tmp...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+	// Entries sort by index then rank, so the last of each index wins.
+	merged := entries[:0]
+	for i, e := range entries {
+		if i+1 < len(entries) && entries[i+1]>>6 == e>>6 {
+			continue
+		}
+		merged = append(merged, e)
+	}
+	h.sparse, h.tmp = merged, h.tmp[:0]
+	if 4*len(h.sparse) > 1<<h.p {
+		h.toDense()
+	}
+}
+
+// toDense converts the sparse list into dense registers.
+func (h *HyperLogLog[T]) toDense() {
+	if h.registers != nil {
+		return
+	}
+	h.registers = make([]uint8, 1<<h.p)
+	for _, e := range h.sparse {
+		h.foldSparse(e)
+	}
+	for _, e := range h.tmp {
+		h.foldSparse(e)
+	}
+	h.sparse, h.tmp = nil, nil
+}
+
+// foldSparse records a sparse entry in the dense registers.
+func (h *HyperLogLog[T]) foldSparse(e uint32) {
+	shift := sparsePrecision - h.p
+	idx25, rho25 := e>>6, uint8(e&63)
+	idx := idx25 >> shift
+	// The low bits of the sparse index are the first bits the dense rank
+	// counts; only when they are all zero does rho25 matter.
+	rest := idx25 & (1<<shift - 1)
+	rho := rho25 + shift
+	if rest != 0 {
+		rho = uint8(bits.LeadingZeros32(rest)-(32-int(shift))) + 1
+	}
+	if rho > h.registers[idx] {
+		h.registers[idx] = rho
+	}
+}
+
+// estimate implements the improved raw estimator from Otmar Ertl,
+// "New cardinality estimation algorithms for HyperLogLog sketches" (2017).
+func estimate(registers []uint8, p uint8) uint64 {
+	q := 64 - int(p)
+	m := float64(len(registers))
+	counts := make([]float64, q+2)
+	for _, r := range registers {
+		counts[r]++
+	}
+	z := m * tau(1-counts[q+1]/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + counts[k])
+	}
+	z += m * sigma(counts[0]/m)
+	return uint64(math.Round(m * m / (2 * math.Ln2 * z)))
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
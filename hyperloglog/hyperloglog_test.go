@@ -0,0 +1,164 @@
+package hyperloglog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// relativeError compares an estimate with the exact size of a set.Set.
+func relativeError(estimate uint64, exact *set.Set[string]) float64 {
+	return math.Abs(float64(estimate)-float64(exact.Size())) / float64(exact.Size())
+}
+
+func TestNewPrecision(t *testing.T) {
+	if _, err := New[string](3); !errors.Is(err, ErrPrecision) {
+		t.Errorf("Expected ErrPrecision for precision 3, got %v", err)
+	}
+	if _, err := New[string](19); !errors.Is(err, ErrPrecision) {
+		t.Errorf("Expected ErrPrecision for precision 19, got %v", err)
+	}
+}
+
+func TestAccuracyAgainstSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const precision = 14
+	// Three standard errors.
+	tolerance := 3 * 1.04 / math.Sqrt(1<<precision)
+
+	for _, n := range []int{10, 1000, 5000, 100000, 500000} {
+		h, _ := New[string](precision)
+		exact := set.New[string]()
+		for i := 0; i < n; i++ {
+			// Draw from a range larger than n so some values repeat.
+			v := fmt.Sprintf("visitor-%d", rng.Intn(2*n))
+			h.Add(v)
+			exact.Add(v)
+		}
+		if err := relativeError(h.Count(), exact); err > tolerance {
+			t.Errorf("n=%d: estimate %d vs exact %d, relative error %.4f > %.4f",
+				n, h.Count(), exact.Size(), err, tolerance)
+		}
+	}
+}
+
+func TestSparseToDense(t *testing.T) {
+	h, _ := New[int](10)
+	for i := 0; i < 100; i++ {
+		h.Add(i)
+	}
+	if !h.IsSparse() {
+		t.Fatalf("Expected sketch to be sparse after 100 elements")
+	}
+	if c := h.Count(); c != 100 {
+		t.Errorf("Expected sparse count to be exact at 100, got %d", c)
+	}
+	for i := 100; i < 5000; i++ {
+		h.Add(i)
+	}
+	if h.IsSparse() {
+		t.Fatalf("Expected sketch to be dense after 5000 elements")
+	}
+}
+
+func TestSparseConversionMatchesDense(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	sparse, _ := New[uint64](12)
+	for i := 0; i < 500; i++ {
+		sparse.AddHash(rng.Uint64())
+	}
+	sparse.mu.Lock()
+	sparse.mergeTmp()
+	sparse.toDense()
+	sparse.mu.Unlock()
+
+	rng = rand.New(rand.NewSource(2))
+	dense, _ := New[uint64](12)
+	dense.registers = make([]uint8, 1<<12)
+	for i := 0; i < 500; i++ {
+		dense.AddHash(rng.Uint64())
+	}
+	for i := range dense.registers {
+		if dense.registers[i] != sparse.registers[i] {
+			t.Fatalf("Register %d: dense %d, converted %d", i, dense.registers[i], sparse.registers[i])
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	exact := set.New[string]()
+	shards := make([]*HyperLogLog[string], 4)
+	for s := range shards {
+		shards[s], _ = New[string](14)
+		// Shards overlap by half.
+		for i := s * 25000; i < s*25000+50000; i++ {
+			v := fmt.Sprint(i)
+			shards[s].Add(v)
+			exact.Add(v)
+		}
+	}
+	total, _ := New[string](14)
+	for _, shard := range shards {
+		if err := total.Merge(shard); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := relativeError(total.Count(), exact); err > 0.025 {
+		t.Errorf("Merged estimate %d vs exact %d, relative error %.4f", total.Count(), exact.Size(), err)
+	}
+
+	small, _ := New[string](14)
+	small.Add("a")
+	sparseTotal, _ := New[string](14)
+	sparseTotal.Add("b")
+	sparseTotal.Merge(small)
+	if c := sparseTotal.Count(); c != 2 {
+		t.Errorf("Expected sparse merge count 2, got %d", c)
+	}
+
+	// A sparse sketch merged into a dense one must reach the registers.
+	dense, _ := New[string](10)
+	for i := 0; i < 5000; i++ {
+		dense.Add(fmt.Sprint(i))
+	}
+	sparse, _ := New[string](10)
+	for i := 5000; i < 5100; i++ {
+		sparse.Add(fmt.Sprint(i))
+	}
+	if dense.IsSparse() || !sparse.IsSparse() {
+		t.Fatalf("Expected a dense and a sparse sketch")
+	}
+	before := dense.Count()
+	dense.Merge(sparse)
+	if after := dense.Count(); after <= before {
+		t.Errorf("Expected merging a sparse sketch to raise the count above %d, got %d", before, after)
+	}
+
+	other, _ := New[string](12)
+	if err := total.Merge(other); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	h, _ := New[int](14)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				h.Add(g*10000 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c := h.Count(); math.Abs(float64(c)-80000)/80000 > 0.03 {
+		t.Errorf("Expected count near 80000, got %d", c)
+	}
+}
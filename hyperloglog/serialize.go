@@ -0,0 +1,90 @@
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// magic identifies the binary serialization of a HyperLogLog.
+const magic = "HLL1"
+
+const (
+	modeSparse = 0
+	modeDense  = 1
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The format is the
+// magic "HLL1", the precision, a mode byte, then either a little-endian
+// uint32 count followed by the sorted sparse entries, or the 2^p dense
+// registers.
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mergeTmp()
+
+	buf := append([]byte(magic), h.p)
+	if h.registers != nil {
+		buf = append(buf, modeDense)
+		return append(buf, h.registers...), nil
+	}
+	buf = append(buf, modeSparse)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(h.sparse)))
+	for _, e := range h.sparse {
+		buf = binary.LittleEndian.AppendUint32(buf, e)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the
+// precision and contents of the sketch. The hasher is kept, so it must
+// match the one used by the writer.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(magic)+2 || string(data[:len(magic)]) != magic {
+		return fmt.Errorf("%w: bad header", ErrInvalidFormat)
+	}
+	p, mode, body := data[len(magic)], data[len(magic)+1], data[len(magic)+2:]
+	if p < MinPrecision || p > MaxPrecision {
+		return fmt.Errorf("%w: precision %d", ErrInvalidFormat, p)
+	}
+
+	var registers []uint8
+	var sparse []uint32
+	switch mode {
+	case modeDense:
+		if len(body) != 1<<p {
+			return fmt.Errorf("%w: expected %d registers, got %d", ErrInvalidFormat, 1<<p, len(body))
+		}
+		registers = append([]uint8(nil), body...)
+		for _, r := range registers {
+			if int(r) > 64-int(p)+1 {
+				return fmt.Errorf("%w: register value %d", ErrInvalidFormat, r)
+			}
+		}
+	case modeSparse:
+		if len(body) < 4 {
+			return fmt.Errorf("%w: missing sparse count", ErrInvalidFormat)
+		}
+		n := binary.LittleEndian.Uint32(body)
+		body = body[4:]
+		if uint64(len(body)) != 4*uint64(n) {
+			return fmt.Errorf("%w: expected %d sparse entries", ErrInvalidFormat, n)
+		}
+		sparse = make([]uint32, n)
+		for i := range sparse {
+			sparse[i] = binary.LittleEndian.Uint32(body[4*i:])
+			if e := sparse[i]; e>>6 >= 1<<sparsePrecision || e&63 < 1 || e&63 > 64-sparsePrecision+1 {
+				return fmt.Errorf("%w: sparse entry %#x", ErrInvalidFormat, e)
+			}
+			if i > 0 && sparse[i]>>6 <= sparse[i-1]>>6 {
+				return fmt.Errorf("%w: sparse entries out of order", ErrInvalidFormat)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %d", ErrInvalidFormat, mode)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.p, h.registers, h.sparse, h.tmp = p, registers, sparse, nil
+	return nil
+}
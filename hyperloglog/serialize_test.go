@@ -0,0 +1,47 @@
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestSerialization(t *testing.T) {
+	for _, n := range []int{50, 50000} {
+		h, _ := New[int](12)
+		for i := 0; i < n; i++ {
+			h.Add(i)
+		}
+		data, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		decoded, _ := New[int](4)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if decoded.Precision() != 12 || decoded.Count() != h.Count() {
+			t.Errorf("n=%d: expected precision 12 count %d, got %d and %d",
+				n, h.Count(), decoded.Precision(), decoded.Count())
+		}
+		if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat for truncated data, got %v", err)
+		}
+	}
+}
+
+func TestUnmarshalInvalidSparseEntry(t *testing.T) {
+	for _, e := range []uint32{
+		1<<6 | 0,         // rank 0
+		1<<6 | 41,        // rank above 64-25+1
+		1<<31 | 1<<6 | 1, // index wider than 25 bits
+	} {
+		data := append([]byte(magic), 12, modeSparse)
+		data = binary.LittleEndian.AppendUint32(data, 1)
+		data = binary.LittleEndian.AppendUint32(data, e)
+		h, _ := New[int](12)
+		if err := h.UnmarshalBinary(data); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat for entry %#x, got %v", e, err)
+		}
+	}
+}
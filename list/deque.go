@@ -0,0 +1,238 @@
+package list
+
+import "reflect"
+
+// Deque is a double-ended queue backed by a growable ring buffer. Pushing
+// and popping at either end take amortized O(1) time, unlike Insert(0, v)
+// and Pop(0) on a List, which copy the whole list.
+//
+// A Deque created with a maxlen is bounded like Python's
+// collections.deque: once it holds maxlen elements, pushing at one end
+// discards an element from the other.
+//
+// The zero value is an empty, unbounded deque ready to use.
+type Deque[T any] struct {
+	buf     []T // ring buffer; the elements are buf[head], ..., wrapping around
+	head    int
+	size    int
+	maxlen  int
+	bounded bool
+}
+
+// NewDeque creates and returns an empty Deque. An optional maxlen bounds
+// its length; a negative maxlen is treated as zero.
+func NewDeque[T any](maxlen ...int) *Deque[T] {
+	d := &Deque[T]{}
+	if len(maxlen) > 0 {
+		d.maxlen, d.bounded = max(maxlen[0], 0), true
+	}
+	return d
+}
+
+// DequeFromList creates a Deque holding the elements of l in order, bounded
+// by an optional maxlen as in NewDeque. A bounded deque keeps the last
+// maxlen elements. It returns a *TypeError wrapping ErrWrongType if an
+// element of l is not a T.
+func DequeFromList[T any](l *List, maxlen ...int) (*Deque[T], error) {
+	d := NewDeque[T](maxlen...)
+	for i, v := range l.data {
+		elem, ok := v.(T)
+		if !ok && (v != nil || !nilable[T]()) {
+			return nil, &TypeError{
+				Index: i,
+				Types: []reflect.Type{reflect.TypeOf((*T)(nil)).Elem(), reflect.TypeOf(v)},
+				Err:   ErrWrongType,
+			}
+		}
+		d.PushBack(elem)
+	}
+	return d, nil
+}
+
+// nilable reports whether nil converts to a T.
+func nilable[T any]() bool {
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
+// ToList returns a List holding the deque's elements from front to back.
+func (d *Deque[T]) ToList() *List {
+	data := make([]any, d.size)
+	for i := range data {
+		data[i] = d.buf[d.slot(i)]
+	}
+	return &List{data: data}
+}
+
+// slot returns the buffer position of the i-th element from the front.
+func (d *Deque[T]) slot(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// grow makes room for one more element, doubling the buffer when it is full.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+// PushBack adds element at the back. In a full bounded deque it first
+// discards the front element.
+func (d *Deque[T]) PushBack(element T) {
+	if d.bounded && d.maxlen == 0 {
+		return
+	}
+	if d.bounded && d.size == d.maxlen {
+		d.PopFront()
+	}
+	d.grow()
+	d.buf[d.slot(d.size)] = element
+	d.size++
+}
+
+// PushFront adds element at the front. In a full bounded deque it first
+// discards the back element.
+func (d *Deque[T]) PushFront(element T) {
+	if d.bounded && d.maxlen == 0 {
+		return
+	}
+	if d.bounded && d.size == d.maxlen {
+		d.PopBack()
+	}
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = element
+	d.size++
+}
+
+// PopFront removes and returns the front element, or returns ErrEmpty.
+func (d *Deque[T]) PopFront() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmpty
+	}
+	element := d.buf[d.head]
+	d.buf[d.head] = zero // release the reference
+	d.head = d.slot(1)
+	d.size--
+	return element, nil
+}
+
+// PopBack removes and returns the back element, or returns ErrEmpty.
+func (d *Deque[T]) PopBack() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmpty
+	}
+	i := d.slot(d.size - 1)
+	element := d.buf[i]
+	d.buf[i] = zero // release the reference
+	d.size--
+	return element, nil
+}
+
+// PeekFront returns the front element without removing it, or returns
+// ErrEmpty.
+func (d *Deque[T]) PeekFront() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return d.buf[d.head], nil
+}
+
+// PeekBack returns the back element without removing it, or returns
+// ErrEmpty.
+func (d *Deque[T]) PeekBack() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return d.buf[d.slot(d.size-1)], nil
+}
+
+// At returns the element at index, counting from the front. Negative
+// indices count from the back.
+func (d *Deque[T]) At(index int) (T, error) {
+	i, err := checkIndex(index, d.size)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return d.buf[d.slot(i)], nil
+}
+
+// Rotate moves the last k elements to the front, like Python's
+// deque.rotate. A negative k moves the first -k elements to the back.
+func (d *Deque[T]) Rotate(k int) {
+	if d.size == 0 {
+		return
+	}
+	k %= d.size
+	if k < 0 {
+		k += d.size
+	}
+	if d.size == len(d.buf) {
+		// The buffer is full, so the ring can turn in place.
+		d.head = (d.head - k + d.size) % d.size
+		return
+	}
+	// Move whichever side is shorter, one element at a time.
+	if k <= d.size/2 {
+		for ; k > 0; k-- {
+			src, dst := d.slot(d.size-1), (d.head-1+len(d.buf))%len(d.buf)
+			d.buf[dst], d.buf[src] = d.buf[src], d.buf[dst]
+			d.head = dst
+		}
+		return
+	}
+	for k = d.size - k; k > 0; k-- {
+		src, dst := d.head, d.slot(d.size)
+		d.buf[dst], d.buf[src] = d.buf[src], d.buf[dst]
+		d.head = d.slot(1)
+	}
+}
+
+// MaxLen returns the deque's bound and true, or 0 and false if it is
+// unbounded.
+func (d *Deque[T]) MaxLen() (int, bool) {
+	return d.maxlen, d.bounded
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+func (d *Deque[T]) Clear() {
+	d.buf, d.head, d.size = nil, 0, 0
+}
+
+// ToSlice returns the elements from front to back.
+func (d *Deque[T]) ToSlice() []T {
+	result := make([]T, d.size)
+	for i := range result {
+		result[i] = d.buf[d.slot(i)]
+	}
+	return result
+}
+
+// Iterator returns a channel that yields the elements from front to back,
+// as they were when Iterator was called.
+func (d *Deque[T]) Iterator() <-chan T {
+	elements := d.ToSlice()
+	ch := make(chan T)
+	go func() {
+		for _, v := range elements {
+			ch <- v
+		}
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,241 @@
+package list
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDequeEnds(t *testing.T) {
+	d := NewDeque[int]()
+	for i := 1; i <= 3; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+	}
+	if got := d.ToSlice(); !reflect.DeepEqual(got, []int{-3, -2, -1, 1, 2, 3}) {
+		t.Errorf("Expected [-3 -2 -1 1 2 3], got %v", got)
+	}
+	if v, err := d.PeekFront(); err != nil || v != -3 {
+		t.Errorf("PeekFront() = %v, %v; want -3", v, err)
+	}
+	if v, err := d.PeekBack(); err != nil || v != 3 {
+		t.Errorf("PeekBack() = %v, %v; want 3", v, err)
+	}
+	if v, err := d.PopFront(); err != nil || v != -3 {
+		t.Errorf("PopFront() = %v, %v; want -3", v, err)
+	}
+	if v, err := d.PopBack(); err != nil || v != 3 {
+		t.Errorf("PopBack() = %v, %v; want 3", v, err)
+	}
+	if d.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", d.Len())
+	}
+
+	d.Clear()
+	if _, err := d.PopFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from PopFront, got %v", err)
+	}
+	if _, err := d.PopBack(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from PopBack, got %v", err)
+	}
+	if _, err := d.PeekFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from PeekFront, got %v", err)
+	}
+	if _, err := d.PeekBack(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from PeekBack, got %v", err)
+	}
+}
+
+func TestDequeAt(t *testing.T) {
+	d := NewDeque[string]()
+	for _, s := range []string{"b", "c"} {
+		d.PushBack(s)
+	}
+	d.PushFront("a")
+	for index, want := range map[int]string{0: "a", 2: "c", -1: "c", -3: "a"} {
+		if got, err := d.At(index); err != nil || got != want {
+			t.Errorf("At(%d) = %q, %v; want %q", index, got, err, want)
+		}
+	}
+	var indexErr *IndexError
+	if _, err := d.At(3); !errors.As(err, &indexErr) || indexErr.Length != 3 {
+		t.Errorf("Expected an *IndexError for At(3), got %v", err)
+	}
+}
+
+// TestDequeAgainstSlice checks random operations against a plain slice,
+// across enough pushes and pops to wrap and grow the ring buffer.
+func TestDequeAgainstSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := NewDeque[int]()
+	model := []int{}
+	for i := 0; i < 5000; i++ {
+		switch op := rng.Intn(5); {
+		case op == 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case op == 1:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case op == 2 && len(model) > 0:
+			v, _ := d.PopFront()
+			if v != model[0] {
+				t.Fatalf("Step %d: PopFront() = %d, want %d", i, v, model[0])
+			}
+			model = model[1:]
+		case op == 3 && len(model) > 0:
+			v, _ := d.PopBack()
+			if v != model[len(model)-1] {
+				t.Fatalf("Step %d: PopBack() = %d, want %d", i, v, model[len(model)-1])
+			}
+			model = model[:len(model)-1]
+		case op == 4:
+			k := rng.Intn(21) - 10
+			d.Rotate(k)
+			model = rotated(model, k)
+		}
+		if got := d.ToSlice(); !reflect.DeepEqual(got, model) {
+			t.Fatalf("Step %d: deque holds %v, want %v", i, got, model)
+		}
+	}
+}
+
+// rotated is Python's deque.rotate on a slice.
+func rotated(s []int, k int) []int {
+	if len(s) == 0 {
+		return s
+	}
+	k = ((k % len(s)) + len(s)) % len(s)
+	return append(append([]int{}, s[len(s)-k:]...), s[:len(s)-k]...)
+}
+
+func TestDequeRotate(t *testing.T) {
+	tests := []struct {
+		k        int
+		expected []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{5, 1, 2, 3, 4}},
+		{4, []int{2, 3, 4, 5, 1}},
+		{-2, []int{3, 4, 5, 1, 2}},
+		{12, []int{4, 5, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		for _, capacity := range []int{5, 8} {
+			d := NewDeque[int]()
+			for i := 1; i <= capacity; i++ {
+				d.PushBack(i)
+			}
+			for d.Len() > 5 {
+				d.PopBack()
+			}
+			d.Rotate(tt.k)
+			if got := d.ToSlice(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Rotate(%d) with %d slots = %v, want %v", tt.k, capacity, got, tt.expected)
+			}
+		}
+	}
+}
+
+func TestDequeMaxLen(t *testing.T) {
+	d := NewDeque[int](3)
+	for i := 1; i <= 5; i++ {
+		d.PushBack(i)
+	}
+	if got := d.ToSlice(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], got %v", got)
+	}
+	d.PushFront(0)
+	if got := d.ToSlice(); !reflect.DeepEqual(got, []int{0, 3, 4}) {
+		t.Errorf("Expected [0 3 4], got %v", got)
+	}
+	if n, ok := d.MaxLen(); n != 3 || !ok {
+		t.Errorf("MaxLen() = %d, %v; want 3, true", n, ok)
+	}
+	if _, ok := NewDeque[int]().MaxLen(); ok {
+		t.Errorf("Expected an unbounded deque")
+	}
+
+	var unbounded Deque[int]
+	unbounded.PushBack(1)
+	unbounded.PushFront(0)
+	if got := unbounded.ToSlice(); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("Expected the zero Deque to be unbounded, got %v", got)
+	}
+
+	zero := NewDeque[int](0)
+	zero.PushBack(1)
+	zero.PushFront(2)
+	if zero.Len() != 0 {
+		t.Errorf("Expected a deque with maxlen 0 to stay empty, got length %d", zero.Len())
+	}
+}
+
+func TestDequeListConversion(t *testing.T) {
+	l := newList(1, 2, 3, 4)
+	d, err := DequeFromList[int](l, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.ToSlice(); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("Expected the last three elements, got %v", got)
+	}
+	d.PushFront(0)
+	if got := d.ToList().data; !reflect.DeepEqual(got, []any{0, 2, 3}) {
+		t.Errorf("Expected [0 2 3], got %v", got)
+	}
+
+	_, err = DequeFromList[int](newList(1, "two"))
+	var typeErr *TypeError
+	if !errors.As(err, &typeErr) || !errors.Is(err, ErrWrongType) || typeErr.Index != 1 {
+		t.Errorf("Expected an ErrWrongType *TypeError at index 1, got %v", err)
+	}
+
+	errs, err := DequeFromList[error](newList(errors.New("x"), nil))
+	if err != nil || errs.Len() != 2 {
+		t.Errorf("Expected nil to convert to an interface type, got %v", err)
+	}
+	if _, err := DequeFromList[int](newList(nil)); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected nil not to convert to int, got %v", err)
+	}
+}
+
+func TestDequeIterator(t *testing.T) {
+	d := NewDeque[int]()
+	d.PushBack(2)
+	d.PushFront(1)
+	var got []int
+	for v := range d.Iterator() {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", got)
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	const n = 1000
+	b.Run("Deque", func(b *testing.B) {
+		d := NewDeque[int]()
+		for i := 0; i < n; i++ {
+			d.PushBack(i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			v, _ := d.PopFront()
+			d.PushBack(v)
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := New()
+		for i := 0; i < n; i++ {
+			l.Append(i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			v, _ := l.Pop(0)
+			l.Append(v)
+		}
+	})
+}
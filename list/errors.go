@@ -0,0 +1,87 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Sentinel errors returned by List methods, possibly wrapped in one of the
+// structured error types below. Match them with errors.Is.
+var (
+	// ErrIndexOutOfRange is returned for an index outside the list.
+	ErrIndexOutOfRange = errors.New("index out of bounds")
+	// ErrNotFound is returned when a value is not in the list.
+	ErrNotFound = errors.New("element not found")
+	// ErrZeroStep is returned for a slice step of zero.
+	ErrZeroStep = errors.New("step cannot be zero")
+	// ErrSliceLength is returned when assigning to an extended slice a
+	// different number of values than it selects.
+	ErrSliceLength = errors.New("extended slice length mismatch")
+	// ErrMixedTypes is returned when sorting elements that cannot be
+	// compared with each other.
+	ErrMixedTypes = errors.New("cannot sort a list with mixed data types")
+	// ErrUnsupportedType is returned when sorting elements of a type with
+	// no defined order.
+	ErrUnsupportedType = errors.New("unsupported type for sorting")
+	// ErrInvalidWeight is returned by weighted sampling for negative, NaN
+	// or infinite weights, or when every weight is zero.
+	ErrInvalidWeight = errors.New("invalid sampling weight")
+	// ErrEmpty is returned when popping or peeking at an empty deque.
+	ErrEmpty = errors.New("deque is empty")
+	// ErrWrongType is returned when converting a List holding an element
+	// of another type to a typed container such as Deque.
+	ErrWrongType = errors.New("element has the wrong type")
+)
+
+// IndexError reports an index outside a list of the given length. It
+// matches ErrIndexOutOfRange.
+type IndexError struct {
+	Index  int // the index as passed, before negative indices are wrapped
+	Length int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("%v: index %d, length %d", ErrIndexOutOfRange, e.Index, e.Length)
+}
+
+func (e *IndexError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
+
+// TypeError reports elements whose types prevent sorting or conversion.
+// For ErrMixedTypes, Types holds the type of the first element and of the
+// element at Index that conflicts with it; for ErrUnsupportedType, it holds
+// the unsupported type of the element at Index; for ErrWrongType, it holds
+// the wanted type and the type of the element at Index.
+type TypeError struct {
+	Index int
+	Types []reflect.Type
+	Err   error // ErrMixedTypes, ErrUnsupportedType or ErrWrongType
+}
+
+func (e *TypeError) Error() string {
+	names := make([]string, len(e.Types))
+	for i, t := range e.Types {
+		names[i] = fmt.Sprint(t)
+	}
+	return fmt.Sprintf("%v: %v at index %d", e.Err, names, e.Index)
+}
+
+func (e *TypeError) Unwrap() error {
+	return e.Err
+}
+
+// SliceLengthError reports an assignment of Values values to an extended
+// slice selecting Selected elements. It matches ErrSliceLength.
+type SliceLengthError struct {
+	Values, Selected int
+}
+
+func (e *SliceLengthError) Error() string {
+	return fmt.Sprintf("%v: assigning %d values to a slice of %d", ErrSliceLength, e.Values, e.Selected)
+}
+
+func (e *SliceLengthError) Unwrap() error {
+	return ErrSliceLength
+}
@@ -0,0 +1,116 @@
+package list
+
+import "reflect"
+
+// wrapIndex converts a negative index, which counts back from the end of a
+// sequence of length n, into an offset from the start. Every method taking
+// an index or slice bounds goes through it.
+func wrapIndex(i, n int) int {
+	if i < 0 {
+		return i + n
+	}
+	return i
+}
+
+// checkIndex wraps i and checks that it addresses one of n elements.
+func checkIndex(i, n int) (int, error) {
+	j := wrapIndex(i, n)
+	if j < 0 || j >= n {
+		return 0, &IndexError{Index: i, Length: n}
+	}
+	return j, nil
+}
+
+// clampSliceIndex wraps a slice bound and clamps it as Python does: to
+// [0, n] for a positive step and to [-1, n-1] for a negative one.
+func clampSliceIndex(i, n, step int) int {
+	i = wrapIndex(i, n)
+	lower, upper := 0, n
+	if step < 0 {
+		lower, upper = -1, n-1
+	}
+	if i < lower {
+		return lower
+	}
+	if i > upper {
+		return upper
+	}
+	return i
+}
+
+// sliceIndices returns the positions selected by slice bounds on a
+// sequence of length n, in slice order. Slice, SetSlice and DeleteSlice all
+// use it, so they agree on every combination of bounds.
+func sliceIndices(start, end, step, n int) ([]int, error) {
+	if step == 0 {
+		return nil, ErrZeroStep
+	}
+	start, end = clampSliceIndex(start, n, step), clampSliceIndex(end, n, step)
+	var indices []int
+	if step > 0 {
+		for i := start; i < end; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		for i := start; i > end; i += step {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
+
+// Get returns the element at index. Negative indices count from the end.
+func (l *List) Get(index int) (any, error) {
+	i, err := checkIndex(index, len(l.data))
+	if err != nil {
+		return nil, err
+	}
+	return l.data[i], nil
+}
+
+// Set replaces the element at index. Negative indices count from the end.
+func (l *List) Set(index int, element any) error {
+	i, err := checkIndex(index, len(l.data))
+	if err != nil {
+		return err
+	}
+	l.data[i] = element
+	return nil
+}
+
+// Index returns the position of the first element equal to element, like
+// Python's list.index. The optional start and end bounds restrict the
+// search to Slice(start, end) and are normalised the same way.
+func (l *List) Index(element any, bounds ...int) (int, error) {
+	n := len(l.data)
+	start, end := 0, n
+	if len(bounds) > 0 {
+		start = clampSliceIndex(bounds[0], n, 1)
+	}
+	if len(bounds) > 1 {
+		end = clampSliceIndex(bounds[1], n, 1)
+	}
+	for i := start; i < end; i++ {
+		if reflect.DeepEqual(l.data[i], element) {
+			return i, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// Count returns the number of elements equal to element.
+func (l *List) Count(element any) int {
+	count := 0
+	for _, v := range l.data {
+		if reflect.DeepEqual(v, element) {
+			count++
+		}
+	}
+	return count
+}
+
+// Contains reports whether any element is equal to element.
+func (l *List) Contains(element any) bool {
+	_, err := l.Index(element)
+	return err == nil
+}
@@ -0,0 +1,151 @@
+package list
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newList(values ...any) *List {
+	l := New()
+	l.Extend(values)
+	return l
+}
+
+func TestGet(t *testing.T) {
+	l := newList("a", "b", "c")
+	tests := []struct {
+		index    int
+		expected any
+	}{
+		{0, "a"}, {2, "c"}, {-1, "c"}, {-3, "a"},
+	}
+	for _, tt := range tests {
+		got, err := l.Get(tt.index)
+		if err != nil || got != tt.expected {
+			t.Errorf("Get(%d) = %v, %v; want %v", tt.index, got, err, tt.expected)
+		}
+	}
+	for _, index := range []int{3, -4} {
+		if _, err := l.Get(index); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("Get(%d): expected ErrIndexOutOfRange, got %v", index, err)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	l := newList(1, 2, 3)
+	if err := l.Set(-1, 30); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := l.Set(0, 10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(l.data, []any{10, 2, 30}) {
+		t.Errorf("Expected [10 2 30], got %v", l.data)
+	}
+	if err := l.Set(-4, 0); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+}
+
+func TestPopDefaults(t *testing.T) {
+	l := newList(1, 2, 3, 4)
+	if v, err := l.Pop(); err != nil || v != 4 {
+		t.Errorf("Pop() = %v, %v; want 4", v, err)
+	}
+	if v, err := l.Pop(-3); err != nil || v != 1 {
+		t.Errorf("Pop(-3) = %v, %v; want 1", v, err)
+	}
+	if !reflect.DeepEqual(l.data, []any{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", l.data)
+	}
+	if _, err := newList().Pop(); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange from an empty list, got %v", err)
+	}
+}
+
+func TestInsertNegative(t *testing.T) {
+	tests := []struct {
+		index    int
+		expected []any
+	}{
+		{-1, []any{1, 2, "x", 3}}, // before the last element, as in Python
+		{-3, []any{"x", 1, 2, 3}},
+		{3, []any{1, 2, 3, "x"}},
+		{100, []any{1, 2, 3, "x"}},  // clamped to the end
+		{-100, []any{"x", 1, 2, 3}}, // clamped to the start
+	}
+	for _, tt := range tests {
+		l := newList(1, 2, 3)
+		if err := l.Insert(tt.index, "x"); err != nil {
+			t.Errorf("Insert(%d): unexpected error: %v", tt.index, err)
+			continue
+		}
+		if !reflect.DeepEqual(l.data, tt.expected) {
+			t.Errorf("Insert(%d): expected %v, got %v", tt.index, tt.expected, l.data)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	l := newList("a", "b", "a", []int{1}, "a")
+	tests := []struct {
+		element  any
+		bounds   []int
+		expected int
+	}{
+		{"a", nil, 0},
+		{"a", []int{1}, 2},
+		{"a", []int{-2}, 4},
+		{[]int{1}, nil, 3},
+		{"b", []int{-100, 100}, 1},
+	}
+	for _, tt := range tests {
+		got, err := l.Index(tt.element, tt.bounds...)
+		if err != nil || got != tt.expected {
+			t.Errorf("Index(%v, %v) = %d, %v; want %d", tt.element, tt.bounds, got, err, tt.expected)
+		}
+	}
+	for _, bounds := range [][]int{{2, 4}, {3, 1}, {-1, -1}} {
+		if _, err := l.Index("b", bounds...); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Index(b, %v): expected ErrNotFound, got %v", bounds, err)
+		}
+	}
+}
+
+func TestCountAndContains(t *testing.T) {
+	l := newList(1, "1", 1, 1.0)
+	if c := l.Count(1); c != 2 {
+		t.Errorf("Expected Count(1) = 2, got %d", c)
+	}
+	if !l.Contains("1") || l.Contains(2) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+	if err := l.Remove(7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected Remove to return ErrNotFound, got %v", err)
+	}
+}
+
+func TestStructuredErrors(t *testing.T) {
+	_, err := newList(1, 2).Get(-5)
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != -5 || indexErr.Length != 2 {
+		t.Errorf("Expected an IndexError for index -5 and length 2, got %v", err)
+	}
+	if err.Error() != "index out of bounds: index -5, length 2" {
+		t.Errorf("Unexpected message: %v", err)
+	}
+
+	err = newList(1, 2, 3).SetSlice(0, 3, 2, []any{1})
+	var lengthErr *SliceLengthError
+	if !errors.As(err, &lengthErr) || lengthErr.Values != 1 || lengthErr.Selected != 2 {
+		t.Errorf("Expected a SliceLengthError for 1 value and 2 selected, got %v", err)
+	}
+
+	err = newList("a", nil).Sort()
+	var typeErr *TypeError
+	if !errors.As(err, &typeErr) || !errors.Is(err, ErrMixedTypes) || typeErr.Index != 1 {
+		t.Errorf("Expected ErrMixedTypes at index 1 for a nil element, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package list
+
+// Node is an element of a LinkedList. A *Node stays valid as a handle
+// while its element is in the list, however the list around it changes.
+type Node[T any] struct {
+	Value T
+
+	next, prev *Node[T]
+	list       *LinkedList[T]
+}
+
+// Next returns the following node, or nil at the back of the list.
+func (n *Node[T]) Next() *Node[T] {
+	if next := n.next; n.list != nil && next != &n.list.root {
+		return next
+	}
+	return nil
+}
+
+// Prev returns the preceding node, or nil at the front of the list.
+func (n *Node[T]) Prev() *Node[T] {
+	if prev := n.prev; n.list != nil && prev != &n.list.root {
+		return prev
+	}
+	return nil
+}
+
+// LinkedList is a doubly linked list. Given a *Node, inserting next to it,
+// moving it and removing it take O(1) time. Methods shared with List, such
+// as Append, Extend, Len and Reverse, behave the same way.
+//
+// The zero value is an empty list ready to use.
+type LinkedList[T any] struct {
+	root Node[T] // sentinel; root.next is the front and root.prev the back
+	size int
+}
+
+// NewLinkedList creates and returns a new, empty LinkedList.
+func NewLinkedList[T any]() *LinkedList[T] {
+	return new(LinkedList[T]).init()
+}
+
+// init links the sentinel to itself the first time the list is used.
+func (l *LinkedList[T]) init() *LinkedList[T] {
+	if l.root.next == nil {
+		l.root.next, l.root.prev = &l.root, &l.root
+	}
+	return l
+}
+
+// owns reports whether n is a node of l.
+func (l *LinkedList[T]) owns(n *Node[T]) bool {
+	return n != nil && n.list == l
+}
+
+// link places n after at.
+func (l *LinkedList[T]) link(n, at *Node[T]) *Node[T] {
+	n.prev, n.next = at, at.next
+	at.next.prev = n
+	at.next = n
+	n.list = l
+	l.size++
+	return n
+}
+
+// unlink removes n from the list without clearing its owner.
+func (l *LinkedList[T]) unlink(n *Node[T]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	l.size--
+}
+
+// Front returns the first node, or nil if the list is empty.
+func (l *LinkedList[T]) Front() *Node[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last node, or nil if the list is empty.
+func (l *LinkedList[T]) Back() *Node[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Append adds element at the back and returns its node.
+func (l *LinkedList[T]) Append(element T) *Node[T] {
+	l.init()
+	return l.link(&Node[T]{Value: element}, l.root.prev)
+}
+
+// Prepend adds element at the front and returns its node.
+func (l *LinkedList[T]) Prepend(element T) *Node[T] {
+	l.init()
+	return l.link(&Node[T]{Value: element}, &l.root)
+}
+
+// Extend appends elements at the back, in order.
+func (l *LinkedList[T]) Extend(elements []T) {
+	for _, e := range elements {
+		l.Append(e)
+	}
+}
+
+// InsertBefore adds element just before mark and returns its node. It
+// returns ErrNotFound if mark is not in the list.
+func (l *LinkedList[T]) InsertBefore(element T, mark *Node[T]) (*Node[T], error) {
+	if !l.owns(mark) {
+		return nil, ErrNotFound
+	}
+	return l.link(&Node[T]{Value: element}, mark.prev), nil
+}
+
+// InsertAfter adds element just after mark and returns its node. It
+// returns ErrNotFound if mark is not in the list.
+func (l *LinkedList[T]) InsertAfter(element T, mark *Node[T]) (*Node[T], error) {
+	if !l.owns(mark) {
+		return nil, ErrNotFound
+	}
+	return l.link(&Node[T]{Value: element}, mark), nil
+}
+
+// Remove removes node from the list. It returns ErrNotFound if node is not
+// in the list, including when it has already been removed.
+func (l *LinkedList[T]) Remove(node *Node[T]) error {
+	if !l.owns(node) {
+		return ErrNotFound
+	}
+	l.unlink(node)
+	node.next, node.prev, node.list = nil, nil, nil
+	return nil
+}
+
+// MoveToFront moves node to the front of the list. It returns ErrNotFound
+// if node is not in the list.
+func (l *LinkedList[T]) MoveToFront(node *Node[T]) error {
+	if !l.owns(node) {
+		return ErrNotFound
+	}
+	if l.root.next != node {
+		l.unlink(node)
+		l.link(node, &l.root)
+	}
+	return nil
+}
+
+// MoveToBack moves node to the back of the list. It returns ErrNotFound if
+// node is not in the list.
+func (l *LinkedList[T]) MoveToBack(node *Node[T]) error {
+	if !l.owns(node) {
+		return ErrNotFound
+	}
+	if l.root.prev != node {
+		l.unlink(node)
+		l.link(node, l.root.prev)
+	}
+	return nil
+}
+
+// Splice moves every node of other to the back of l, in order, leaving
+// other empty. The nodes keep their identity, so handles into other become
+// handles into l. Splicing a list into itself does nothing.
+func (l *LinkedList[T]) Splice(other *LinkedList[T]) {
+	if other == l || other.size == 0 {
+		return
+	}
+	l.init()
+	for n := other.root.next; n != &other.root; n = n.next {
+		n.list = l
+	}
+	first, last := other.root.next, other.root.prev
+	first.prev, last.next = l.root.prev, &l.root
+	l.root.prev.next, l.root.prev = first, last
+	l.size += other.size
+	other.root.next, other.root.prev, other.size = &other.root, &other.root, 0
+}
+
+// Clear removes every node. Handles to the removed nodes are no longer in
+// the list.
+func (l *LinkedList[T]) Clear() {
+	for n := l.Front(); n != nil; {
+		next := n.Next()
+		n.next, n.prev, n.list = nil, nil, nil
+		n = next
+	}
+	l.root.next, l.root.prev, l.size = &l.root, &l.root, 0
+}
+
+func (l *LinkedList[T]) Len() int {
+	return l.size
+}
+
+// Reverse reverses the order of the list in place. Nodes keep their values,
+// so handles stay valid.
+func (l *LinkedList[T]) Reverse() {
+	if l.size == 0 {
+		return
+	}
+	n := &l.root
+	for {
+		n.next, n.prev = n.prev, n.next
+		n = n.prev // the old next
+		if n == &l.root {
+			return
+		}
+	}
+}
+
+// ToSlice returns the values from front to back.
+func (l *LinkedList[T]) ToSlice() []T {
+	result := make([]T, 0, l.size)
+	for n := l.Front(); n != nil; n = n.Next() {
+		result = append(result, n.Value)
+	}
+	return result
+}
+
+// Iterator returns a channel that yields the values from front to back, as
+// they were when Iterator was called.
+func (l *LinkedList[T]) Iterator() <-chan T {
+	return sendAll(l.ToSlice(), false)
+}
+
+// ReverseIterator returns a channel that yields the values from back to
+// front, as they were when ReverseIterator was called.
+func (l *LinkedList[T]) ReverseIterator() <-chan T {
+	return sendAll(l.ToSlice(), true)
+}
+
+// sendAll sends values on a new channel, last to first if backward, and
+// then closes it.
+func sendAll[T any](values []T, backward bool) <-chan T {
+	ch := make(chan T)
+	go func() {
+		for i := range values {
+			if backward {
+				i = len(values) - 1 - i
+			}
+			ch <- values[i]
+		}
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,186 @@
+package list
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func linkedOf(values ...int) *LinkedList[int] {
+	l := NewLinkedList[int]()
+	l.Extend(values)
+	return l
+}
+
+// backward walks l from the back using Prev, to check the reverse links.
+func backward(l *LinkedList[int]) []int {
+	result := []int{}
+	for n := l.Back(); n != nil; n = n.Prev() {
+		result = append([]int{n.Value}, result...)
+	}
+	return result
+}
+
+func expectLinked(t *testing.T, l *LinkedList[int], expected ...int) {
+	t.Helper()
+	if expected == nil {
+		expected = []int{}
+	}
+	if got := l.ToSlice(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := backward(l); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v walking backward, got %v", expected, got)
+	}
+	if l.Len() != len(expected) {
+		t.Errorf("Expected length %d, got %d", len(expected), l.Len())
+	}
+}
+
+func TestLinkedListInsert(t *testing.T) {
+	l := NewLinkedList[int]()
+	two := l.Append(2)
+	l.Prepend(0)
+	if _, err := l.InsertBefore(1, two); err != nil {
+		t.Fatal(err)
+	}
+	four, err := l.InsertAfter(4, two)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.InsertBefore(3, four)
+	expectLinked(t, l, 0, 1, 2, 3, 4)
+	if l.Front().Value != 0 || l.Back().Value != 4 {
+		t.Errorf("Expected front 0 and back 4, got %d and %d", l.Front().Value, l.Back().Value)
+	}
+}
+
+func TestLinkedListZeroValue(t *testing.T) {
+	var l LinkedList[int]
+	if l.Front() != nil || l.Back() != nil || l.Len() != 0 {
+		t.Errorf("Expected an empty list")
+	}
+	l.Prepend(1)
+	l.Append(2)
+	expectLinked(t, &l, 1, 2)
+}
+
+func TestLinkedListRemove(t *testing.T) {
+	l := NewLinkedList[int]()
+	a, b, c := l.Append(1), l.Append(2), l.Append(3)
+	if err := l.Remove(b); err != nil {
+		t.Fatal(err)
+	}
+	expectLinked(t, l, 1, 3)
+	if err := l.Remove(b); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound removing a node twice, got %v", err)
+	}
+	if b.Next() != nil || b.Prev() != nil {
+		t.Errorf("Expected a removed node to have no neighbours")
+	}
+	other := linkedOf(9)
+	if err := other.Remove(a); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound removing another list's node, got %v", err)
+	}
+	if _, err := l.InsertAfter(5, b); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound inserting after a removed node, got %v", err)
+	}
+	l.Remove(a)
+	l.Remove(c)
+	expectLinked(t, l)
+}
+
+func TestLinkedListMove(t *testing.T) {
+	l := NewLinkedList[int]()
+	nodes := []*Node[int]{l.Append(1), l.Append(2), l.Append(3)}
+	l.MoveToFront(nodes[2])
+	expectLinked(t, l, 3, 1, 2)
+	l.MoveToBack(nodes[2])
+	expectLinked(t, l, 1, 2, 3)
+	l.MoveToBack(nodes[2])
+	l.MoveToFront(nodes[0])
+	expectLinked(t, l, 1, 2, 3)
+	if err := l.MoveToFront(&Node[int]{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound moving a foreign node, got %v", err)
+	}
+}
+
+// TestLinkedListLRU uses handles the way an LRU cache does.
+func TestLinkedListLRU(t *testing.T) {
+	const capacity = 2
+	order := NewLinkedList[string]()
+	index := map[string]*Node[string]{}
+	touch := func(key string) {
+		if n, ok := index[key]; ok {
+			order.MoveToFront(n)
+			return
+		}
+		index[key] = order.Prepend(key)
+		if order.Len() > capacity {
+			oldest := order.Back()
+			order.Remove(oldest)
+			delete(index, oldest.Value)
+		}
+	}
+	for _, key := range []string{"a", "b", "a", "c", "d", "a"} {
+		touch(key)
+	}
+	if got := order.ToSlice(); !reflect.DeepEqual(got, []string{"a", "d"}) {
+		t.Errorf("Expected [a d], got %v", got)
+	}
+}
+
+func TestLinkedListSplice(t *testing.T) {
+	l, other := linkedOf(1, 2), linkedOf(3, 4)
+	three := other.Front()
+	l.Splice(other)
+	expectLinked(t, l, 1, 2, 3, 4)
+	expectLinked(t, other)
+	if err := l.Remove(three); err != nil {
+		t.Errorf("Expected a spliced node to belong to its new list, got %v", err)
+	}
+	if err := other.Remove(l.Back()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a spliced node to leave its old list, got %v", err)
+	}
+	expectLinked(t, l, 1, 2, 4)
+
+	l.Splice(l)
+	l.Splice(NewLinkedList[int]())
+	expectLinked(t, l, 1, 2, 4)
+	var empty LinkedList[int]
+	empty.Splice(l)
+	expectLinked(t, &empty, 1, 2, 4)
+	other.Append(5)
+	expectLinked(t, other, 5)
+}
+
+func TestLinkedListReverseAndClear(t *testing.T) {
+	l := NewLinkedList[int]()
+	first := l.Append(1)
+	l.Extend([]int{2, 3})
+	l.Reverse()
+	expectLinked(t, l, 3, 2, 1)
+	if l.Back() != first {
+		t.Errorf("Expected handles to survive Reverse")
+	}
+	l.Clear()
+	expectLinked(t, l)
+	if err := l.Remove(first); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a cleared node to be gone, got %v", err)
+	}
+	NewLinkedList[int]().Reverse()
+}
+
+func TestLinkedListIterators(t *testing.T) {
+	l := linkedOf(1, 2, 3)
+	var forward, reverse []int
+	for v := range l.Iterator() {
+		forward = append(forward, v)
+	}
+	for v := range l.ReverseIterator() {
+		reverse = append(reverse, v)
+	}
+	if !reflect.DeepEqual(forward, []int{1, 2, 3}) || !reflect.DeepEqual(reverse, []int{3, 2, 1}) {
+		t.Errorf("Expected [1 2 3] and [3 2 1], got %v and %v", forward, reverse)
+	}
+}
@@ -1,14 +1,14 @@
 package list
 
 import (
-	"errors"
-	"fmt"
+	"log/slog"
 	"reflect"
 	"sort"
 )
 
 type List struct {
-	data []any
+	data   []any
+	logger *slog.Logger
 }
 
 func New() *List {
@@ -25,11 +25,15 @@ func (l *List) Extend(elements []any) {
 	l.data = append(l.data, elements...)
 }
 
+// Insert adds element before index, normalising index as Slice does, like
+// Python's list.insert. A negative index counts from the end, so
+// Insert(-1, v) places v before the last element, and indices past either
+// end clamp to it: Insert(len, v) and above append, and a negative index
+// below -len prepends. The error is always nil and is kept so that existing
+// callers still compile.
 func (l *List) Insert(index int, element any) error {
-	if index < 0 || index > len(l.data) {
-		return errors.New("index out of bounds")
-	}
-	l.data = append(l.data[:index], append([]any{element}, l.data[index:]...)...)
+	i := clampSliceIndex(index, len(l.data), 1)
+	l.data = append(l.data[:i], append([]any{element}, l.data[i:]...)...)
 	return nil
 }
 
@@ -40,15 +44,22 @@ func (l *List) Remove(element any) error {
 			return nil
 		}
 	}
-	return errors.New("element not found")
+	return ErrNotFound
 }
 
-func (l *List) Pop(index int) (any, error) {
-	if index < 0 || index >= len(l.data) {
-		return nil, errors.New("index out of bounds")
+// Pop removes and returns the element at index, which defaults to -1, the
+// last element. Negative indices count from the end.
+func (l *List) Pop(index ...int) (any, error) {
+	i := -1
+	if len(index) > 0 {
+		i = index[0]
+	}
+	i, err := checkIndex(i, len(l.data))
+	if err != nil {
+		return nil, err
 	}
-	element := l.data[index]
-	l.data = append(l.data[:index], l.data[index+1:]...)
+	element := l.data[i]
+	l.data = append(l.data[:i], l.data[i+1:]...)
 	return element, nil
 }
 
@@ -76,42 +87,18 @@ func (l *List) Slice(params ...int) (*List, error) {
 	case 3:
 		start, end, step = params[0], params[1], params[2]
 	}
-	fmt.Println(start, end, step)
-	if step == 0 {
-		return nil, errors.New("step cannot be zero")
-	}
-
-	// Handle negative indices
-	n := len(l.data)
-	if start < 0 {
-		start += n
-	}
-	if end < 0 {
-		end += n
-	}
-
-	if start < 0 {
-		start = 0
-	}
-	if end > n {
-		end = n
-	}
-	if start >= n || end < 0 || (start >= end && step > 0) || (start <= end && step < 0) {
-		return &List{}, nil
+	l.debug("list.Slice", slog.Int("start", start), slog.Int("end", end), slog.Int("step", step))
+	indices, err := sliceIndices(start, end, step, len(l.data))
+	if err != nil {
+		return nil, err
 	}
 
 	slicedData := []any{}
-	if step > 0 {
-		for i := start; i < end; i += step {
-			slicedData = append(slicedData, l.data[i])
-		}
-	} else {
-		for i := start; i > end; i += step {
-			slicedData = append(slicedData, l.data[i])
-		}
+	for _, i := range indices {
+		slicedData = append(slicedData, l.data[i])
 	}
 
-	return &List{data: slicedData}, nil
+	return &List{data: slicedData, logger: l.logger}, nil
 }
 
 func (l *List) Sort(order ...string) error {
@@ -119,15 +106,19 @@ func (l *List) Sort(order ...string) error {
 		return nil
 	}
 
-	if !l.isHomogeneous() {
-		return errors.New("cannot sort a list with mixed data types")
+	if i := l.mismatchIndex(); i >= 0 {
+		return &TypeError{
+			Index: i,
+			Types: []reflect.Type{reflect.TypeOf(l.data[0]), reflect.TypeOf(l.data[i])},
+			Err:   ErrMixedTypes,
+		}
 	}
 
 	isAscending := true
 	if len(order) > 0 && order[0] == "desc" {
 		isAscending = false
 	}
-	fmt.Println(l.data[0])
+	l.debug("list.Sort", slog.Bool("ascending", isAscending), slog.Any("type", reflect.TypeOf(l.data[0])))
 
 	switch l.data[0].(type) {
 	case int, int8, int16, int32, int64,
@@ -147,7 +138,7 @@ func (l *List) Sort(order ...string) error {
 			return l.data[i].(string) > l.data[j].(string)
 		})
 	default:
-		return errors.New("unsupported type for sorting")
+		return &TypeError{Index: 0, Types: []reflect.Type{reflect.TypeOf(l.data[0])}, Err: ErrUnsupportedType}
 	}
 	return nil
 }
@@ -194,28 +185,36 @@ func (l *List) Iterator() <-chan any {
 	return ch
 }
 
-func (l *List) isHomogeneous() bool {
+// mismatchIndex returns the index of the first element whose type cannot
+// be compared with the first element's, or -1 if there is none.
+func (l *List) mismatchIndex() int {
 	if len(l.data) == 0 {
-		return true
+		return -1
 	}
 
 	// Get the type of the first item
 	firstType := reflect.TypeOf(l.data[0])
+	if firstType == nil {
+		return -1 // reported as an unsupported type
+	}
 	isNumber := isNumericBoolType(firstType)
 	isString := firstType.Kind() == reflect.String
 
-	for _, v := range l.data {
+	for i, v := range l.data {
 		currentType := reflect.TypeOf(v)
 
 		// Check if the type is consistent with the first element
+		if (isNumber || isString) && currentType == nil {
+			return i
+		}
 		if isNumber && !isNumericBoolType(currentType) {
-			return false
+			return i
 		}
 		if isString && currentType.Kind() != reflect.String {
-			return false
+			return i
 		}
 	}
-	return true
+	return -1
 }
 
 func isNumericBoolType(t reflect.Type) bool {
@@ -1,6 +1,7 @@
 package list
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -68,9 +69,9 @@ func TestInsert(t *testing.T) {
 		t.Fatalf("Expected [1, 2, 3], got: %v", l.data)
 	}
 
-	err = l.Insert(5, 4) // Invalid index
-	if err == nil {
-		t.Fatal("Expected error, got nil")
+	err = l.Insert(5, 4) // Past the end, so it appends
+	if err != nil || !reflect.DeepEqual(l.data, []any{1, 2, 3, 4}) {
+		t.Fatalf("Expected [1, 2, 3, 4], got: %v, %v", l.data, err)
 	}
 }
 
@@ -210,7 +211,7 @@ func TestSlice(t *testing.T) {
 		t.Fatalf("Expected error, got nil")
 	}
 
-	if err.Error() != "step cannot be zero" {
+	if !errors.Is(err, ErrZeroStep) || err.Error() != "step cannot be zero" {
 		t.Fatalf("Expected error 'step cannot be zero', got: %v", err)
 	}
 
@@ -297,8 +298,12 @@ func TestSort(t *testing.T) {
 		t.Fatalf("Expected error, got nil")
 	}
 
-	if err.Error() != "cannot sort a list with mixed data types" {
-		t.Fatalf("Expected error 'cannot sort a list with mixed data types', got: %v", err)
+	var typeErr *TypeError
+	if !errors.Is(err, ErrMixedTypes) || !errors.As(err, &typeErr) {
+		t.Fatalf("Expected ErrMixedTypes, got: %v", err)
+	}
+	if typeErr.Index != 1 || !reflect.DeepEqual(typeErr.Types, []reflect.Type{reflect.TypeOf(0), reflect.TypeOf("")}) {
+		t.Fatalf("Expected int and string at index 1, got: %v", err)
 	}
 
 	l4 := New()
@@ -323,8 +328,11 @@ func TestSort(t *testing.T) {
 		t.Fatalf("Expected eror, got nil")
 	}
 
-	if err.Error() != "unsupported type for sorting" {
-		t.Fatalf("Expected error 'unsupported type for sorting', got: %v", err)
+	if !errors.Is(err, ErrUnsupportedType) || !errors.As(err, &typeErr) {
+		t.Fatalf("Expected ErrUnsupportedType, got: %v", err)
+	}
+	if typeErr.Types[0] != reflect.TypeOf(person1) {
+		t.Fatalf("Expected the Person type, got: %v", err)
 	}
 }
 
@@ -348,3 +356,27 @@ func TestIterator(t *testing.T) {
 		t.Fatalf("Expected result %v, got: %v", expected, result)
 	}
 }
+
+func TestSliceClampsLikePython(t *testing.T) {
+	l := New()
+	l.Extend([]any{0, 1, 2, 3, 4})
+	tests := []struct {
+		params   []int
+		expected []any
+	}{
+		{[]int{4, -6, -1}, []any{4, 3, 2, 1, 0}}, // [::-1]
+		{[]int{10, -10, -2}, []any{4, 2, 0}},     // out-of-range start and end
+		{[]int{-10, 10}, []any{0, 1, 2, 3, 4}},
+		{[]int{3, 10, -1}, []any{}},
+		{[]int{-2, -10, -1}, []any{3, 2, 1, 0}},
+	}
+	for _, tt := range tests {
+		sliced, err := l.Slice(tt.params...)
+		if err != nil {
+			t.Fatalf("Slice(%v): unexpected error: %v", tt.params, err)
+		}
+		if len(sliced.data) != len(tt.expected) || (len(tt.expected) > 0 && !reflect.DeepEqual(sliced.data, tt.expected)) {
+			t.Errorf("Slice(%v) = %v, want %v", tt.params, sliced.data, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// defaultLogger is used by lists without a logger of their own. It is nil
+// by default, so lists log nothing.
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetDefaultLogger sets the logger used by every list that has no logger
+// of its own. Operations are logged at slog.LevelDebug. Pass nil to make
+// lists silent again.
+func SetDefaultLogger(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// SetLogger sets the logger that traces operations on l, overriding the
+// package default. Pass nil to fall back to the default.
+func (l *List) SetLogger(logger *slog.Logger) {
+	l.logger = logger
+}
+
+// debug logs an operation on the list's logger, or the package default,
+// when one is configured and enabled for debug records.
+func (l *List) debug(msg string, args ...any) {
+	logger := l.logger
+	if logger == nil {
+		logger = defaultLogger.Load()
+	}
+	if logger == nil || !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	logger.Debug(msg, append(args, slog.Int("len", len(l.data)))...)
+}
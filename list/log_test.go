@@ -0,0 +1,115 @@
+package list
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns everything f writes to os.Stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = saved }()
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+	f()
+	w.Close()
+	return string(<-done)
+}
+
+func exercise(t *testing.T, l *List) {
+	t.Helper()
+	if _, err := l.Slice(0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Sort("desc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetSlice(0, 1, 1, []any{9, 8}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.DeleteSlice(0, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSilentByDefault(t *testing.T) {
+	out := captureStdout(t, func() { exercise(t, newList(3, 1, 2, 5)) })
+	if out != "" {
+		t.Errorf("Expected no output on stdout, got %q", out)
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := newList(3, 1, 2, 5)
+	l.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	out := captureStdout(t, func() { exercise(t, l) })
+	if out != "" {
+		t.Errorf("Expected no output on stdout, got %q", out)
+	}
+	for _, want := range []string{
+		"msg=list.Slice start=0 end=3 step=1 len=4",
+		"msg=list.Sort ascending=false type=int len=4",
+		"msg=list.SetSlice start=0 end=1 step=1 values=2 len=4",
+		"msg=list.DeleteSlice start=0 end=2 step=1 len=5",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected log to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	sliced, _ := l.Slice(1)
+	buf.Reset()
+	sliced.Sort()
+	if !strings.Contains(buf.String(), "msg=list.Sort") {
+		t.Errorf("Expected a sliced list to inherit the logger, got:\n%s", buf.String())
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := newList(2, 1)
+	l.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	l.Sort()
+	if buf.Len() != 0 {
+		t.Errorf("Expected no debug records at the default info level, got:\n%s", buf.String())
+	}
+}
+
+func TestSetDefaultLogger(t *testing.T) {
+	var global, own bytes.Buffer
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	SetDefaultLogger(slog.New(slog.NewTextHandler(&global, opts)))
+	defer SetDefaultLogger(nil)
+
+	a, b := newList(2, 1), newList(2, 1)
+	b.SetLogger(slog.New(slog.NewTextHandler(&own, opts)))
+	a.Sort()
+	b.Sort("desc")
+	if !strings.Contains(global.String(), "ascending=true") || strings.Contains(global.String(), "ascending=false") {
+		t.Errorf("Expected only the first list in the default log, got:\n%s", global.String())
+	}
+	if !strings.Contains(own.String(), "ascending=false") {
+		t.Errorf("Expected the second list in its own log, got:\n%s", own.String())
+	}
+
+	SetDefaultLogger(nil)
+	global.Reset()
+	a.Sort()
+	if global.Len() != 0 {
+		t.Errorf("Expected no records after clearing the default logger, got:\n%s", global.String())
+	}
+}
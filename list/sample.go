@@ -0,0 +1,81 @@
+package list
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// weights evaluates weight for every element and returns the weights and
+// their total.
+func (l *List) weights(weight func(any) float64) ([]float64, float64, error) {
+	ws := make([]float64, len(l.data))
+	total := 0.0
+	for i, v := range l.data {
+		w := weight(v)
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, 0, fmt.Errorf("%w: element %d has weight %v", ErrInvalidWeight, i, w)
+		}
+		ws[i] = w
+		total += w
+	}
+	if total == 0 {
+		return nil, 0, fmt.Errorf("%w: total weight is zero", ErrInvalidWeight)
+	}
+	return ws, total, nil
+}
+
+// WeightedChoice returns one element chosen with probability proportional
+// to weight(element), using rng.
+func (l *List) WeightedChoice(weight func(any) float64, rng *rand.Rand) (any, error) {
+	ws, total, err := l.weights(weight)
+	if err != nil {
+		return nil, err
+	}
+	target := rng.Float64() * total
+	for i, w := range ws {
+		if target < w {
+			return l.data[i], nil
+		}
+		target -= w
+	}
+	// Rounding can leave target just above the last weight; fall back to
+	// the last element that can be chosen.
+	for i := len(ws) - 1; ; i-- {
+		if ws[i] > 0 {
+			return l.data[i], nil
+		}
+	}
+}
+
+// WeightedSample returns up to k elements chosen without replacement, each
+// draw picking from the remaining elements with probability proportional to
+// weight(element). Elements are returned in the order they were drawn, and
+// elements of zero weight are never chosen. It uses the Efraimidis-Spirakis
+// method, keying each element by log(u)/w for a uniform u.
+func (l *List) WeightedSample(k int, weight func(any) float64, rng *rand.Rand) ([]any, error) {
+	ws, _, err := l.weights(weight)
+	if err != nil {
+		return nil, err
+	}
+	type keyed struct {
+		index int
+		key   float64
+	}
+	candidates := make([]keyed, 0, len(ws))
+	for i, w := range ws {
+		if w > 0 {
+			candidates = append(candidates, keyed{index: i, key: math.Log(1-rng.Float64()) / w})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].key > candidates[j].key })
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	result := make([]any, 0, max(k, 0))
+	for _, c := range candidates[:max(k, 0)] {
+		result = append(result, l.data[c.index])
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package list
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func weightOf(v any) float64 {
+	return float64(v.(int))
+}
+
+func TestWeightedChoice(t *testing.T) {
+	l := New()
+	l.Extend([]any{0, 1, 3})
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[any]int)
+	const draws = 40000
+	for i := 0; i < draws; i++ {
+		v, err := l.WeightedChoice(weightOf, rng)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		counts[v]++
+	}
+	if counts[0] != 0 {
+		t.Errorf("Expected a zero-weight element never to be chosen, got %d", counts[0])
+	}
+	if c := counts[3]; c < draws*3/4*95/100 || c > draws*3/4*105/100 {
+		t.Errorf("Expected 3 to be chosen about %d times, got %d", draws*3/4, c)
+	}
+}
+
+func TestWeightedSample(t *testing.T) {
+	l := New()
+	l.Extend([]any{1, 2, 0, 7})
+	rng := rand.New(rand.NewSource(2))
+	first := make(map[any]int)
+	const draws = 20000
+	for i := 0; i < draws; i++ {
+		sample, err := l.WeightedSample(5, weightOf, rng)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(sample) != 3 {
+			t.Fatalf("Expected the three positive-weight elements, got %v", sample)
+		}
+		first[sample[0]]++
+	}
+	// The first draw follows the weights, 7 out of a total of 10.
+	if c := first[7]; c < draws*7/10*95/100 || c > draws*7/10*105/100 {
+		t.Errorf("Expected 7 to be drawn first about %d times, got %d", draws*7/10, c)
+	}
+	if sample, _ := l.WeightedSample(0, weightOf, rng); len(sample) != 0 {
+		t.Errorf("Expected an empty sample for k = 0, got %v", sample)
+	}
+}
+
+func TestWeightedErrors(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	l := New()
+	if _, err := l.WeightedChoice(weightOf, rng); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected ErrInvalidWeight for an empty list, got %v", err)
+	}
+	l.Extend([]any{0, 0})
+	if _, err := l.WeightedSample(1, weightOf, rng); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected ErrInvalidWeight when every weight is zero, got %v", err)
+	}
+	l.Append(-1)
+	if _, err := l.WeightedChoice(weightOf, rng); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("Expected ErrInvalidWeight for a negative weight, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package list
+
+import "log/slog"
+
+// SetSlice replaces the elements selected by Slice(start, end, step) with
+// values, like Python's l[start:end:step] = values. With a step of 1 the
+// slice may grow or shrink the list; when end is before start, values are
+// inserted at start. Any other step selects an extended slice, which needs
+// exactly as many values as it selects.
+func (l *List) SetSlice(start, end, step int, values []any) error {
+	l.debug("list.SetSlice", slog.Int("start", start), slog.Int("end", end), slog.Int("step", step), slog.Int("values", len(values)))
+	values = append([]any(nil), values...) // values may alias l.data
+	if step == 1 {
+		n := len(l.data)
+		start, end = clampSliceIndex(start, n, step), clampSliceIndex(end, n, step)
+		if end < start {
+			end = start
+		}
+		data := make([]any, 0, n-(end-start)+len(values))
+		data = append(data, l.data[:start]...)
+		data = append(data, values...)
+		l.data = append(data, l.data[end:]...)
+		return nil
+	}
+
+	indices, err := sliceIndices(start, end, step, len(l.data))
+	if err != nil {
+		return err
+	}
+	if len(values) != len(indices) {
+		return &SliceLengthError{Values: len(values), Selected: len(indices)}
+	}
+	for k, i := range indices {
+		l.data[i] = values[k]
+	}
+	return nil
+}
+
+// DeleteSlice removes the elements selected by Slice(start, end, step),
+// like Python's del l[start:end:step].
+func (l *List) DeleteSlice(start, end, step int) error {
+	l.debug("list.DeleteSlice", slog.Int("start", start), slog.Int("end", end), slog.Int("step", step))
+	indices, err := sliceIndices(start, end, step, len(l.data))
+	if err != nil {
+		return err
+	}
+	deleted := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		deleted[i] = true
+	}
+	kept := l.data[:0]
+	for i, v := range l.data {
+		if !deleted[i] {
+			kept = append(kept, v)
+		}
+	}
+	// Clear the tail so removed elements can be garbage collected.
+	for i := len(kept); i < len(l.data); i++ {
+		l.data[i] = nil
+	}
+	l.data = kept
+	return nil
+}
@@ -0,0 +1,112 @@
+package list
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func zeroToFour() *List {
+	return newList(0, 1, 2, 3, 4)
+}
+
+// The expected results below were produced by running the same operations
+// on list(range(5)) in CPython.
+
+func TestSetSliceMatchesPython(t *testing.T) {
+	tests := []struct {
+		start, end, step int
+		values           []any
+		expected         []any
+		lengthErr        bool
+	}{
+		{1, 3, 1, []any{"a", "b", "c"}, []any{0, "a", "b", "c", 3, 4}, false},
+		{1, 3, 1, []any{}, []any{0, 3, 4}, false},
+		{0, 0, 1, []any{"a"}, []any{"a", 0, 1, 2, 3, 4}, false},
+		{5, 5, 1, []any{"a"}, []any{0, 1, 2, 3, 4, "a"}, false},
+		{4, 1, 1, []any{"a"}, []any{0, 1, 2, 3, "a", 4}, false},
+		{-2, 100, 1, []any{"a"}, []any{0, 1, 2, "a"}, false},
+		{-100, 2, 1, []any{"a", "b", "c"}, []any{"a", "b", "c", 2, 3, 4}, false},
+		{0, 5, 2, []any{"a", "b", "c"}, []any{"a", 1, "b", 3, "c"}, false},
+		{1, 5, 2, []any{"a", "b"}, []any{0, "a", 2, "b", 4}, false},
+		{4, -6, -1, []any{"a", "b", "c", "d", "e"}, []any{"e", "d", "c", "b", "a"}, false},
+		{-1, -6, -2, []any{"a", "b", "c"}, []any{"c", 1, "b", 3, "a"}, false},
+		{3, 0, -1, []any{"a", "b", "c"}, []any{0, "c", "b", "a", 4}, false},
+		{1, 1, 2, []any{}, []any{0, 1, 2, 3, 4}, false},
+		{100, -100, -3, []any{"a", "b"}, []any{0, "b", 2, 3, "a"}, false},
+		{0, 5, 2, []any{"a", "b"}, nil, true},
+		{4, -6, -1, []any{"a"}, nil, true},
+		{2, 0, 1, []any{"x", "y"}, []any{0, 1, "x", "y", 2, 3, 4}, false},
+	}
+	for _, tt := range tests {
+		l := zeroToFour()
+		err := l.SetSlice(tt.start, tt.end, tt.step, tt.values)
+		if tt.lengthErr {
+			if !errors.Is(err, ErrSliceLength) {
+				t.Errorf("SetSlice(%d, %d, %d, %v): expected ErrSliceLength, got %v", tt.start, tt.end, tt.step, tt.values, err)
+			}
+			if !reflect.DeepEqual(l.data, zeroToFour().data) {
+				t.Errorf("SetSlice(%d, %d, %d, %v): a failed assignment modified the list: %v", tt.start, tt.end, tt.step, tt.values, l.data)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SetSlice(%d, %d, %d, %v): unexpected error: %v", tt.start, tt.end, tt.step, tt.values, err)
+			continue
+		}
+		if !reflect.DeepEqual(l.data, tt.expected) {
+			t.Errorf("SetSlice(%d, %d, %d, %v) = %v, want %v", tt.start, tt.end, tt.step, tt.values, l.data, tt.expected)
+		}
+	}
+}
+
+func TestDeleteSliceMatchesPython(t *testing.T) {
+	tests := []struct {
+		start, end, step int
+		expected         []any
+	}{
+		{1, 3, 1, []any{0, 3, 4}},
+		{0, 5, 2, []any{1, 3}},
+		{4, -6, -1, []any{}},
+		{-1, -6, -2, []any{1, 3}},
+		{3, 1, 1, []any{0, 1, 2, 3, 4}},
+		{-2, 100, 1, []any{0, 1, 2}},
+		{1, 4, -1, []any{0, 1, 2, 3, 4}},
+		{100, -100, -3, []any{0, 2, 3}},
+		{0, 0, 1, []any{0, 1, 2, 3, 4}},
+		{-100, 100, 4, []any{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		l := zeroToFour()
+		if err := l.DeleteSlice(tt.start, tt.end, tt.step); err != nil {
+			t.Errorf("DeleteSlice(%d, %d, %d): unexpected error: %v", tt.start, tt.end, tt.step, err)
+			continue
+		}
+		if !reflect.DeepEqual(l.data, tt.expected) {
+			t.Errorf("DeleteSlice(%d, %d, %d) = %v, want %v", tt.start, tt.end, tt.step, l.data, tt.expected)
+		}
+	}
+}
+
+func TestSliceAssignmentZeroStep(t *testing.T) {
+	l := zeroToFour()
+	if err := l.SetSlice(0, 5, 0, []any{}); !errors.Is(err, ErrZeroStep) {
+		t.Errorf("SetSlice: expected ErrZeroStep, got %v", err)
+	}
+	if err := l.DeleteSlice(0, 5, 0); !errors.Is(err, ErrZeroStep) {
+		t.Errorf("DeleteSlice: expected ErrZeroStep, got %v", err)
+	}
+	if _, err := l.Slice(0, 5, 0); !errors.Is(err, ErrZeroStep) {
+		t.Errorf("Slice: expected ErrZeroStep, got %v", err)
+	}
+}
+
+func TestSetSliceFromItself(t *testing.T) {
+	l := newList(1, 2, 3)
+	if err := l.SetSlice(0, 0, 1, l.data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(l.data, []any{1, 2, 3, 1, 2, 3}) {
+		t.Errorf("Expected [1 2 3 1 2 3], got %v", l.data)
+	}
+}
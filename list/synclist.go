@@ -0,0 +1,167 @@
+package list
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+)
+
+// SyncList is a thread-safe List. Every method holds a read or write lock
+// for its whole duration, so compound operations such as PopIf and
+// AppendIfAbsent are atomic. Iteration works on a snapshot and does not
+// block writers.
+type SyncList struct {
+	list List
+	mu   sync.RWMutex
+}
+
+// NewSync creates and returns a new, empty SyncList.
+func NewSync() *SyncList {
+	return &SyncList{list: List{data: []any{}}}
+}
+
+// SetLogger sets the logger that traces operations on the list. See
+// List.SetLogger.
+func (s *SyncList) SetLogger(logger *slog.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.SetLogger(logger)
+}
+
+func (s *SyncList) Append(element any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Append(element)
+}
+
+func (s *SyncList) Extend(elements []any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Extend(elements)
+}
+
+// Insert adds element before index, like List.Insert.
+func (s *SyncList) Insert(index int, element any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Insert(index, element)
+}
+
+func (s *SyncList) Remove(element any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Remove(element)
+}
+
+// Pop removes and returns the element at index, like List.Pop.
+func (s *SyncList) Pop(index ...int) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Pop(index...)
+}
+
+// PopIf removes and returns the first element for which pred returns true.
+// It reports false and leaves the list unchanged if there is none. pred is
+// called with the lock held and must not use the list.
+func (s *SyncList) PopIf(pred func(any) bool) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, v := range s.list.data {
+		if pred(v) {
+			s.list.data = append(s.list.data[:i], s.list.data[i+1:]...)
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// AppendIfAbsent appends element unless the list already contains an
+// equal element, and reports whether it was appended.
+func (s *SyncList) AppendIfAbsent(element any) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.list.data {
+		if reflect.DeepEqual(v, element) {
+			return false
+		}
+	}
+	s.list.Append(element)
+	return true
+}
+
+func (s *SyncList) Get(index int) (any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Get(index)
+}
+
+func (s *SyncList) Set(index int, element any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Set(index, element)
+}
+
+func (s *SyncList) Index(element any, bounds ...int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Index(element, bounds...)
+}
+
+func (s *SyncList) Count(element any) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Count(element)
+}
+
+func (s *SyncList) Contains(element any) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Contains(element)
+}
+
+func (s *SyncList) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Clear()
+}
+
+func (s *SyncList) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Len()
+}
+
+func (s *SyncList) Reverse() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Reverse()
+}
+
+// Slice returns the selected elements as a new, unsynchronized List, like
+// List.Slice.
+func (s *SyncList) Slice(params ...int) (*List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Slice(params...)
+}
+
+// Sort sorts the list in place, like List.Sort.
+func (s *SyncList) Sort(order ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Sort(order...)
+}
+
+// Snapshot returns a copy of the list's current contents as a new,
+// unsynchronized List.
+func (s *SyncList) Snapshot() *List {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &List{data: append([]any{}, s.list.data...), logger: s.list.logger}
+}
+
+// Iterator returns a channel that yields the elements the list held when
+// Iterator was called. Writers are not blocked while the channel is read.
+func (s *SyncList) Iterator() <-chan any {
+	return s.Snapshot().Iterator()
+}
@@ -0,0 +1,160 @@
+package list
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSyncListBasics(t *testing.T) {
+	s := NewSync()
+	s.Extend([]any{3, 1, 2})
+	s.Append(5)
+	if err := s.Insert(0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Sort(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Snapshot().data; !reflect.DeepEqual(got, []any{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", got)
+	}
+	sliced, err := s.Slice(-1, 0, -2)
+	if err != nil || !reflect.DeepEqual(sliced.data, []any{5, 3}) {
+		t.Errorf("Slice(-1, 0, -2) = %v, %v; want [5 3]", sliced, err)
+	}
+	if v, err := s.Pop(0); err != nil || v != 1 {
+		t.Errorf("Pop(0) = %v, %v; want 1", v, err)
+	}
+	if err := s.Remove(4); err != nil || s.Contains(4) || s.Len() != 3 {
+		t.Errorf("Expected 4 to be removed, got %v with error %v", s.Snapshot().data, err)
+	}
+}
+
+func TestPopIf(t *testing.T) {
+	s := NewSync()
+	s.Extend([]any{1, 2, 3, 4})
+	v, ok := s.PopIf(func(v any) bool { return v.(int)%2 == 0 })
+	if !ok || v != 2 {
+		t.Errorf("Expected to pop 2, got %v, %v", v, ok)
+	}
+	if _, ok := s.PopIf(func(v any) bool { return v.(int) > 10 }); ok {
+		t.Errorf("Expected no element to match")
+	}
+	if got := s.Snapshot().data; !reflect.DeepEqual(got, []any{1, 3, 4}) {
+		t.Errorf("Expected [1 3 4], got %v", got)
+	}
+}
+
+func TestAppendIfAbsent(t *testing.T) {
+	s := NewSync()
+	if !s.AppendIfAbsent([]int{1}) {
+		t.Errorf("Expected the first append to succeed")
+	}
+	if s.AppendIfAbsent([]int{1}) {
+		t.Errorf("Expected an equal element to be rejected")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", s.Len())
+	}
+}
+
+func TestSyncListSnapshotIsolation(t *testing.T) {
+	s := NewSync()
+	s.Extend([]any{1, 2, 3})
+	it := s.Iterator()
+	first := <-it
+	// The iterator holds no lock, so writers proceed while it is unread.
+	s.Append(4)
+	s.Clear()
+	got := []any{first}
+	for v := range it {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []any{1, 2, 3}) {
+		t.Errorf("Expected the snapshot [1 2 3], got %v", got)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Expected the list to be cleared, got length %d", s.Len())
+	}
+}
+
+// TestSyncListConcurrent is meant to run under the race detector.
+func TestSyncListConcurrent(t *testing.T) {
+	const writers, perWriter = 8, 500
+	s := NewSync()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				s.Append(w*perWriter + i)
+				s.AppendIfAbsent(-1)
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				for range s.Iterator() {
+				}
+				s.Len()
+				s.Contains(-1)
+				if _, err := s.Slice(0, 10); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := s.Count(-1); got != 1 {
+		t.Errorf("Expected AppendIfAbsent to add -1 exactly once, got %d", got)
+	}
+	if s.Len() != writers*perWriter+1 {
+		t.Fatalf("Expected %d elements, got %d", writers*perWriter+1, s.Len())
+	}
+
+	// Drain concurrently: every element must be popped exactly once.
+	var mu sync.Mutex
+	var popped []int
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := s.PopIf(func(any) bool { return true })
+				if !ok {
+					return
+				}
+				mu.Lock()
+				popped = append(popped, v.(int))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := s.Sort(); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	sort.Ints(popped)
+	if len(popped) != writers*perWriter+1 || popped[0] != -1 {
+		t.Fatalf("Expected %d distinct pops starting at -1, got %d", writers*perWriter+1, len(popped))
+	}
+	for i, v := range popped[1:] {
+		if v != i {
+			t.Fatalf("Expected %d at position %d, got %d", i, i+1, v)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package minhash
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+var (
+	// ErrBanding is returned when a signature length is not bands*rows.
+	ErrBanding = errors.New("minhash: signature length does not match bands*rows")
+	// ErrBandShape is returned by NewLSH when bands or rows is below 1.
+	ErrBandShape = errors.New("minhash: bands and rows must be at least 1")
+)
+
+// LSH is a thread-safe locality-sensitive hashing index over MinHash
+// signatures. Signatures are split into bands of rows; two keys become
+// candidates when any band matches exactly, which happens with probability
+// 1-(1-s^rows)^bands for sets of Jaccard similarity s.
+type LSH[K comparable] struct {
+	bands, rows int
+	buckets     []map[uint64][]K
+	mu          sync.RWMutex
+}
+
+// NewLSH creates an index for signatures of length bands*rows. It returns
+// ErrBandShape unless both are at least 1.
+func NewLSH[K comparable](bands, rows int) (*LSH[K], error) {
+	if bands < 1 || rows < 1 {
+		return nil, ErrBandShape
+	}
+	buckets := make([]map[uint64][]K, bands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]K)
+	}
+	return &LSH[K]{bands: bands, rows: rows, buckets: buckets}, nil
+}
+
+// Threshold returns the approximate similarity (1/bands)^(1/rows) above
+// which pairs are likely to become candidates.
+func (l *LSH[K]) Threshold() float64 {
+	return math.Pow(1/float64(l.bands), 1/float64(l.rows))
+}
+
+// bandHashes hashes each band of a signature into one value.
+func (l *LSH[K]) bandHashes(signature []uint64) ([]uint64, error) {
+	if len(signature) != l.bands*l.rows {
+		return nil, ErrBanding
+	}
+	hashes := make([]uint64, l.bands)
+	for b := range hashes {
+		h := uint64(b)
+		for _, v := range signature[b*l.rows : (b+1)*l.rows] {
+			h = hasher.Mix(h ^ v)
+		}
+		hashes[b] = h
+	}
+	return hashes, nil
+}
+
+// Insert indexes a signature under key.
+func (l *LSH[K]) Insert(key K, signature []uint64) error {
+	hashes, err := l.bandHashes(signature)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for b, h := range hashes {
+		l.buckets[b][h] = append(l.buckets[b][h], key)
+	}
+	return nil
+}
+
+// Query returns the keys sharing at least one band with the signature.
+func (l *LSH[K]) Query(signature []uint64) (*set.Set[K], error) {
+	hashes, err := l.bandHashes(signature)
+	if err != nil {
+		return nil, err
+	}
+	result := set.New[K]()
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for b, h := range hashes {
+		for _, key := range l.buckets[b][h] {
+			result.Add(key)
+		}
+	}
+	return result, nil
+}
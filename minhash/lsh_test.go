@@ -0,0 +1,50 @@
+package minhash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLSHQuery(t *testing.T) {
+	const bands, rows = 32, 4
+	index, err := NewLSH[string](bands, rows)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	docs := map[string]*MinHash[string]{
+		"base":    FromSet(tokens(0, 1000), bands*rows, 3),
+		"similar": FromSet(tokens(50, 1000), bands*rows, 3),
+		"other":   FromSet(tokens(10000, 1000), bands*rows, 3),
+	}
+	for key, sig := range docs {
+		if err := index.Insert(key, sig.Signature()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	candidates, err := index.Query(docs["base"].Signature())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !candidates.Contains("base") || !candidates.Contains("similar") {
+		t.Errorf("Expected base and similar as candidates, got %v", candidates.ToSlice())
+	}
+	if candidates.Contains("other") {
+		t.Errorf("Expected dissimilar document not to be a candidate")
+	}
+}
+
+func TestLSHBanding(t *testing.T) {
+	index, _ := NewLSH[int](16, 8)
+	if err := index.Insert(1, make([]uint64, 100)); !errors.Is(err, ErrBanding) {
+		t.Errorf("Expected ErrBanding, got %v", err)
+	}
+	if th := index.Threshold(); th < 0.70 || th > 0.71 {
+		t.Errorf("Expected threshold near 0.707, got %v", th)
+	}
+	for _, shape := range [][2]int{{0, 4}, {4, 0}, {-1, 4}} {
+		if _, err := NewLSH[int](shape[0], shape[1]); !errors.Is(err, ErrBandShape) {
+			t.Errorf("NewLSH(%d, %d): expected ErrBandShape, got %v", shape[0], shape[1], err)
+		}
+	}
+}
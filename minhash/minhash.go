@@ -0,0 +1,114 @@
+// Package minhash implements MinHash signatures for estimating the Jaccard
+// similarity of sets, and locality-sensitive hashing (LSH) to find
+// similar sets without comparing every pair.
+package minhash
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// ErrIncompatible is returned when comparing or merging signatures with
+// different numbers of permutations or seeds.
+var ErrIncompatible = errors.New("minhash: signatures have different parameters")
+
+// MinHash is a thread-safe MinHash signature. Each permutation keeps the
+// minimum of an independent hash over every element added, so the fraction
+// of matching minimums between two signatures estimates their Jaccard
+// similarity with a standard error of about 1/sqrt(permutations).
+type MinHash[T any] struct {
+	seed uint64
+	mins []uint64
+	hash hasher.Func[T]
+	mu   sync.RWMutex
+}
+
+// New creates a signature with the given number of permutations, using the
+// default hasher for T. Signatures are only comparable when they share the
+// number of permutations and seed.
+func New[T comparable](permutations int, seed uint64) *MinHash[T] {
+	return NewWithHasher(permutations, seed, hasher.For[T]())
+}
+
+// NewWithHasher creates a signature with the given number of permutations,
+// hashing elements with h.
+func NewWithHasher[T any](permutations int, seed uint64, h hasher.Func[T]) *MinHash[T] {
+	if permutations < 1 {
+		permutations = 1
+	}
+	mins := make([]uint64, permutations)
+	for i := range mins {
+		mins[i] = math.MaxUint64
+	}
+	return &MinHash[T]{seed: seed, mins: mins, hash: h}
+}
+
+// FromSet builds a signature over every element of s.
+func FromSet[T comparable](s *set.Set[T], permutations int, seed uint64) *MinHash[T] {
+	m := New[T](permutations, seed)
+	s.ForEach(m.Add)
+	return m
+}
+
+// Add records an element in the signature.
+func (m *MinHash[T]) Add(elem T) {
+	h := m.hash(elem)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.mins {
+		// Each permutation mixes the element hash with its own seed.
+		if v := hasher.Mix(h ^ hasher.Mix(m.seed+uint64(i))); v < m.mins[i] {
+			m.mins[i] = v
+		}
+	}
+}
+
+// Permutations returns the length of the signature.
+func (m *MinHash[T]) Permutations() int {
+	return len(m.mins)
+}
+
+// Signature returns a copy of the per-permutation minimums.
+func (m *MinHash[T]) Signature() []uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]uint64(nil), m.mins...)
+}
+
+// Jaccard estimates the Jaccard similarity of the sets behind m and other.
+func (m *MinHash[T]) Jaccard(other *MinHash[T]) (float64, error) {
+	if m.seed != other.seed || len(m.mins) != len(other.mins) {
+		return 0, ErrIncompatible
+	}
+	theirs := other.Signature()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	matches := 0
+	for i, v := range m.mins {
+		if v == theirs[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(m.mins)), nil
+}
+
+// Merge folds other into m, so that m becomes the signature of the union
+// of both sets.
+func (m *MinHash[T]) Merge(other *MinHash[T]) error {
+	if m.seed != other.seed || len(m.mins) != len(other.mins) {
+		return ErrIncompatible
+	}
+	theirs := other.Signature()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, v := range theirs {
+		if v < m.mins[i] {
+			m.mins[i] = v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package minhash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// tokens returns a set of n tokens starting at offset.
+func tokens(offset, n int) *set.Set[string] {
+	s := set.New[string]()
+	for i := offset; i < offset+n; i++ {
+		s.Add(fmt.Sprintf("token-%d", i))
+	}
+	return s
+}
+
+func TestJaccardEstimate(t *testing.T) {
+	tests := []struct {
+		a, b *set.Set[string]
+	}{
+		{tokens(0, 1000), tokens(0, 1000)},
+		{tokens(0, 1000), tokens(500, 1000)},
+		{tokens(0, 1000), tokens(900, 1000)},
+		{tokens(0, 1000), tokens(5000, 1000)},
+	}
+	for _, tt := range tests {
+		exact := set.Jaccard(tt.a, tt.b)
+		est, err := FromSet(tt.a, 512, 1).Jaccard(FromSet(tt.b, 512, 1))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(est-exact) > 3/math.Sqrt(512) {
+			t.Errorf("Expected estimate near %.3f, got %.3f", exact, est)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := FromSet(tokens(0, 100), 128, 7)
+	b := FromSet(tokens(100, 100), 128, 7)
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	union := FromSet(tokens(0, 200), 128, 7)
+	if sim, _ := a.Jaccard(union); sim != 1 {
+		t.Errorf("Expected merged signature to equal the union signature, similarity %v", sim)
+	}
+}
+
+func TestIncompatible(t *testing.T) {
+	a := New[string](64, 1)
+	if _, err := a.Jaccard(New[string](32, 1)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible for different lengths, got %v", err)
+	}
+	if err := a.Merge(New[string](64, 2)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible for different seeds, got %v", err)
+	}
+}
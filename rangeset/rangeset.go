@@ -0,0 +1,236 @@
+// Package rangeset implements a thread-safe set of values stored as
+// disjoint half-open intervals, for port ranges, IP ranges and time windows
+// that are too large to enumerate.
+package rangeset
+
+import (
+	"cmp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Range is the half-open interval [Lo, Hi). A range with Lo >= Hi is empty.
+type Range[T any] struct {
+	Lo, Hi T
+}
+
+// RangeSet is a thread-safe set of values kept as sorted, disjoint,
+// non-adjacent half-open ranges. Overlapping or touching ranges are
+// coalesced as they are added.
+type RangeSet[T any] struct {
+	ranges  []Range[T]
+	compare func(a, b T) int
+	mu      sync.RWMutex
+}
+
+// New creates and returns a new, empty RangeSet for an ordered type.
+func New[T cmp.Ordered]() *RangeSet[T] {
+	return NewFunc[T](cmp.Compare[T])
+}
+
+// NewFunc creates an empty RangeSet ordered by compare, which returns a
+// negative number, zero or a positive number when a is less than, equal
+// to or greater than b.
+func NewFunc[T any](compare func(a, b T) int) *RangeSet[T] {
+	return &RangeSet[T]{compare: compare}
+}
+
+// NewTime creates an empty RangeSet of time windows.
+func NewTime() *RangeSet[time.Time] {
+	return NewFunc(func(a, b time.Time) int { return a.Compare(b) })
+}
+
+// Add inserts every value in [lo, hi), merging with the ranges it
+// overlaps or touches.
+func (s *RangeSet[T]) Add(lo, hi T) {
+	if s.compare(lo, hi) >= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(lo, hi)
+}
+
+func (s *RangeSet[T]) add(lo, hi T) {
+	// i is the first range ending at or after lo, so it may touch [lo, hi).
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.compare(s.ranges[i].Hi, lo) >= 0 })
+	j := i
+	for j < len(s.ranges) && s.compare(s.ranges[j].Lo, hi) <= 0 {
+		if s.compare(s.ranges[j].Lo, lo) < 0 {
+			lo = s.ranges[j].Lo
+		}
+		if s.compare(s.ranges[j].Hi, hi) > 0 {
+			hi = s.ranges[j].Hi
+		}
+		j++
+	}
+	merged := Range[T]{Lo: lo, Hi: hi}
+	s.ranges = append(s.ranges[:i], append([]Range[T]{merged}, s.ranges[j:]...)...)
+}
+
+// Remove deletes every value in [lo, hi), splitting ranges as needed.
+func (s *RangeSet[T]) Remove(lo, hi T) {
+	if s.compare(lo, hi) >= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(lo, hi)
+}
+
+func (s *RangeSet[T]) remove(lo, hi T) {
+	// i is the first range ending after lo, so it may overlap [lo, hi).
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.compare(s.ranges[i].Hi, lo) > 0 })
+	var kept []Range[T]
+	j := i
+	for j < len(s.ranges) && s.compare(s.ranges[j].Lo, hi) < 0 {
+		r := s.ranges[j]
+		if s.compare(r.Lo, lo) < 0 {
+			kept = append(kept, Range[T]{Lo: r.Lo, Hi: lo})
+		}
+		if s.compare(r.Hi, hi) > 0 {
+			kept = append(kept, Range[T]{Lo: hi, Hi: r.Hi})
+		}
+		j++
+	}
+	s.ranges = append(s.ranges[:i], append(kept, s.ranges[j:]...)...)
+}
+
+// Contains checks if a value is in the set.
+func (s *RangeSet[T]) Contains(x T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.compare(s.ranges[i].Hi, x) > 0 })
+	return i < len(s.ranges) && s.compare(s.ranges[i].Lo, x) <= 0
+}
+
+// ContainsRange checks if every value in [lo, hi) is in the set.
+func (s *RangeSet[T]) ContainsRange(lo, hi T) bool {
+	if s.compare(lo, hi) >= 0 {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.compare(s.ranges[i].Hi, lo) > 0 })
+	return i < len(s.ranges) && s.compare(s.ranges[i].Lo, lo) <= 0 && s.compare(s.ranges[i].Hi, hi) >= 0
+}
+
+// Len returns the number of disjoint ranges in the set.
+func (s *RangeSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.ranges)
+}
+
+// IsEmpty reports whether the set holds no values.
+func (s *RangeSet[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Ranges returns the disjoint ranges of the set in ascending order.
+func (s *RangeSet[T]) Ranges() []Range[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Range[T](nil), s.ranges...)
+}
+
+// ForEach applies the provided function to each range in ascending order.
+func (s *RangeSet[T]) ForEach(f func(Range[T])) {
+	for _, r := range s.Ranges() {
+		f(r)
+	}
+}
+
+// Iterator returns a channel yielding the ranges in ascending order.
+func (s *RangeSet[T]) Iterator() <-chan Range[T] {
+	ch := make(chan Range[T])
+	go func() {
+		for _, r := range s.Ranges() {
+			ch <- r
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Clear removes every range from the set.
+func (s *RangeSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ranges = nil
+}
+
+// Copy returns a new set with the same ranges and ordering.
+func (s *RangeSet[T]) Copy() *RangeSet[T] {
+	return &RangeSet[T]{ranges: s.Ranges(), compare: s.compare}
+}
+
+// Equal checks if both sets hold the same values.
+func (s *RangeSet[T]) Equal(other *RangeSet[T]) bool {
+	mine, theirs := s.Ranges(), other.Ranges()
+	if len(mine) != len(theirs) {
+		return false
+	}
+	for i := range mine {
+		if s.compare(mine[i].Lo, theirs[i].Lo) != 0 || s.compare(mine[i].Hi, theirs[i].Hi) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Complement returns a new set holding the values of [lo, hi) that are not
+// in s.
+func (s *RangeSet[T]) Complement(lo, hi T) *RangeSet[T] {
+	result := NewFunc(s.compare)
+	result.Add(lo, hi)
+	for _, r := range s.Ranges() {
+		result.remove(r.Lo, r.Hi)
+	}
+	return result
+}
+
+// Union returns a new set that is the union of s and another set.
+func (s *RangeSet[T]) Union(other *RangeSet[T]) *RangeSet[T] {
+	result := s.Copy()
+	for _, r := range other.Ranges() {
+		result.add(r.Lo, r.Hi)
+	}
+	return result
+}
+
+// Intersection returns a new set that is the intersection of s and another set.
+func (s *RangeSet[T]) Intersection(other *RangeSet[T]) *RangeSet[T] {
+	mine, theirs := s.Ranges(), other.Ranges()
+	result := NewFunc(s.compare)
+	i, j := 0, 0
+	for i < len(mine) && j < len(theirs) {
+		lo, hi := mine[i].Lo, mine[i].Hi
+		if s.compare(theirs[j].Lo, lo) > 0 {
+			lo = theirs[j].Lo
+		}
+		if s.compare(theirs[j].Hi, hi) < 0 {
+			hi = theirs[j].Hi
+		}
+		if s.compare(lo, hi) < 0 {
+			result.ranges = append(result.ranges, Range[T]{Lo: lo, Hi: hi})
+		}
+		// Advance whichever range ends first.
+		if s.compare(mine[i].Hi, theirs[j].Hi) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a new set that is the difference of s and another set.
+func (s *RangeSet[T]) Difference(other *RangeSet[T]) *RangeSet[T] {
+	result := s.Copy()
+	for _, r := range other.Ranges() {
+		result.remove(r.Lo, r.Hi)
+	}
+	return result
+}
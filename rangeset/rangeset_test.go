@@ -0,0 +1,156 @@
+package rangeset
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func ranges(pairs ...int) []Range[int] {
+	result := []Range[int]{}
+	for i := 0; i < len(pairs); i += 2 {
+		result = append(result, Range[int]{Lo: pairs[i], Hi: pairs[i+1]})
+	}
+	return result
+}
+
+func TestAddCoalesces(t *testing.T) {
+	s := New[int]()
+	s.Add(10, 20)
+	s.Add(30, 40)
+	s.Add(20, 25) // touches [10, 20)
+	s.Add(5, 5)   // empty
+	if got := s.Ranges(); !reflect.DeepEqual(got, ranges(10, 25, 30, 40)) {
+		t.Errorf("Expected [10,25) [30,40), got %v", got)
+	}
+	s.Add(0, 100)
+	if got := s.Ranges(); !reflect.DeepEqual(got, ranges(0, 100)) {
+		t.Errorf("Expected [0,100), got %v", got)
+	}
+}
+
+func TestRemoveSplits(t *testing.T) {
+	s := New[int]()
+	s.Add(0, 100)
+	s.Remove(10, 20)
+	s.Remove(50, 150)
+	if got := s.Ranges(); !reflect.DeepEqual(got, ranges(0, 10, 20, 50)) {
+		t.Errorf("Expected [0,10) [20,50), got %v", got)
+	}
+	if s.Contains(10) || !s.Contains(9) || !s.Contains(20) || s.Contains(50) {
+		t.Errorf("Contains does not respect half-open bounds")
+	}
+	if !s.ContainsRange(20, 50) || s.ContainsRange(5, 25) {
+		t.Errorf("ContainsRange returned an unexpected result")
+	}
+}
+
+// TestAgainstBitmap checks random operations against a plain boolean slice.
+func TestAgainstBitmap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New[int]()
+	model := make([]bool, 200)
+	for step := 0; step < 500; step++ {
+		lo := rng.Intn(200)
+		hi := lo + rng.Intn(200-lo+1)
+		add := rng.Intn(2) == 0
+		if add {
+			s.Add(lo, hi)
+		} else {
+			s.Remove(lo, hi)
+		}
+		for x := lo; x < hi; x++ {
+			model[x] = add
+		}
+		for x := range model {
+			if s.Contains(x) != model[x] {
+				t.Fatalf("step %d: Contains(%d) = %v, want %v", step, x, !model[x], model[x])
+			}
+		}
+		rs := s.Ranges()
+		for i := 1; i < len(rs); i++ {
+			if rs[i].Lo <= rs[i-1].Hi {
+				t.Fatalf("step %d: ranges not coalesced: %v", step, rs)
+			}
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := New[int]()
+	a.Add(0, 10)
+	a.Add(20, 30)
+	b := New[int]()
+	b.Add(5, 25)
+
+	tests := []struct {
+		name     string
+		result   *RangeSet[int]
+		expected []Range[int]
+	}{
+		{"Union", a.Union(b), ranges(0, 30)},
+		{"Intersection", a.Intersection(b), ranges(5, 10, 20, 25)},
+		{"Difference", a.Difference(b), ranges(0, 5, 25, 30)},
+		{"Complement", a.Complement(-5, 35), ranges(-5, 0, 10, 20, 30, 35)},
+	}
+	for _, tt := range tests {
+		if got := tt.result.Ranges(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+	if !a.Union(b).Equal(b.Union(a)) {
+		t.Errorf("Expected Union to be commutative")
+	}
+}
+
+func TestTimeWindows(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTime()
+	s.Add(base, base.Add(time.Hour))
+	s.Add(base.Add(time.Hour), base.Add(2*time.Hour))
+	if s.Len() != 1 {
+		t.Errorf("Expected adjacent windows to merge, got %v", s.Ranges())
+	}
+	if !s.Contains(base.Add(90*time.Minute)) || s.Contains(base.Add(2*time.Hour)) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+	free := s.Complement(base, base.Add(24*time.Hour))
+	if got := free.Ranges(); len(got) != 1 || !got[0].Lo.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("Expected free time from 02:00, got %v", got)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s := New[float64]()
+	s.Add(0.5, 1.5)
+	s.Add(-1, 0)
+	result := []Range[float64]{}
+	for r := range s.Iterator() {
+		result = append(result, r)
+	}
+	expected := []Range[float64]{{-1, 0}, {0.5, 1.5}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	s := New[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Add(g*100+i, g*100+i+1)
+				s.Contains(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if got := s.Ranges(); !reflect.DeepEqual(got, ranges(0, 800)) {
+		t.Errorf("Expected [0,800), got %v", got)
+	}
+}
@@ -0,0 +1,171 @@
+// Package reconcile finds the differences between two sets of IDs held by
+// different peers without shipping either set in full.
+//
+// Both peers summarise their set by hash range. The initiator sends
+// fingerprints of a batch of ranges; the responder answers each with a
+// match, a request to split the range further, or its own IDs once the
+// range is small. Only ranges that differ are explored, so the traffic is
+// proportional to the number of differences times the depth of the
+// search rather than to the size of the sets.
+package reconcile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+const (
+	// fanout is the number of subranges a differing range is split into.
+	fanout = 16
+	// leafSize is the largest range, in IDs on either side, that is
+	// resolved by sending its contents instead of splitting it.
+	leafSize = 8
+)
+
+// ErrProtocol is returned when the peer sends an unexpected message.
+var ErrProtocol = errors.New("reconcile: protocol error")
+
+// Result holds the differences found by a reconciliation.
+type Result struct {
+	// LocalOnly holds the IDs this side has and the peer lacks.
+	LocalOnly *set.Set[string]
+	// RemoteOnly holds the IDs the peer has and this side lacks.
+	RemoteOnly *set.Set[string]
+	// Rounds is the number of request/response exchanges used.
+	Rounds int
+}
+
+// request carries range fingerprints from the initiator, or the final
+// differences once no ranges remain.
+type request struct {
+	Ranges []Fingerprint `json:"ranges,omitempty"`
+	Done   *done         `json:"done,omitempty"`
+}
+
+type done struct {
+	InitiatorOnly []string `json:"initiatorOnly"`
+	ResponderOnly []string `json:"responderOnly"`
+}
+
+// reply answers one range: it matches, should be split, or is small enough
+// that the responder sends its IDs.
+type reply struct {
+	Match bool     `json:"match,omitempty"`
+	Split bool     `json:"split,omitempty"`
+	Items []string `json:"items,omitempty"`
+}
+
+type response struct {
+	Replies []reply `json:"replies"`
+}
+
+// Initiate drives a reconciliation of local against the set held by the
+// peer running Respond on the other end of rw.
+func Initiate(rw io.ReadWriter, local *set.Set[string]) (*Result, error) {
+	summary := Summarize(local)
+	enc, dec := json.NewEncoder(rw), json.NewDecoder(rw)
+	result := &Result{LocalOnly: set.New[string](), RemoteOnly: set.New[string]()}
+
+	pending := []Fingerprint{summary.Fingerprint(0, math.MaxUint64)}
+	for len(pending) > 0 {
+		if err := enc.Encode(request{Ranges: pending}); err != nil {
+			return nil, err
+		}
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Replies) != len(pending) {
+			return nil, fmt.Errorf("%w: %d replies for %d ranges", ErrProtocol, len(resp.Replies), len(pending))
+		}
+		result.Rounds++
+
+		var next []Fingerprint
+		for i, r := range resp.Replies {
+			fp := pending[i]
+			switch {
+			case r.Match:
+			case r.Split:
+				if fp.Lo == fp.Hi {
+					return nil, fmt.Errorf("%w: cannot split range [%d, %d]", ErrProtocol, fp.Lo, fp.Hi)
+				}
+				for _, part := range split(fp.Lo, fp.Hi, fanout) {
+					next = append(next, summary.Fingerprint(part[0], part[1]))
+				}
+			default:
+				diffItems(summary.Items(fp.Lo, fp.Hi), r.Items, result)
+			}
+		}
+		pending = next
+	}
+
+	final := done{
+		InitiatorOnly: result.LocalOnly.ToSlice(),
+		ResponderOnly: result.RemoteOnly.ToSlice(),
+	}
+	if err := enc.Encode(request{Done: &final}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Respond answers the reconciliation started by Initiate on the other end
+// of rw, and returns the differences from this side's point of view.
+func Respond(rw io.ReadWriter, local *set.Set[string]) (*Result, error) {
+	summary := Summarize(local)
+	enc, dec := json.NewEncoder(rw), json.NewDecoder(rw)
+	rounds := 0
+	for {
+		var req request
+		if err := dec.Decode(&req); err != nil {
+			return nil, err
+		}
+		if req.Done != nil {
+			return &Result{
+				LocalOnly:  fromSlice(req.Done.ResponderOnly),
+				RemoteOnly: fromSlice(req.Done.InitiatorOnly),
+				Rounds:     rounds,
+			}, nil
+		}
+		if len(req.Ranges) == 0 {
+			return nil, fmt.Errorf("%w: empty request", ErrProtocol)
+		}
+
+		resp := response{Replies: make([]reply, len(req.Ranges))}
+		for i, theirs := range req.Ranges {
+			ours := summary.Fingerprint(theirs.Lo, theirs.Hi)
+			switch {
+			case ours.Matches(theirs):
+				resp.Replies[i].Match = true
+			case ours.Count <= leafSize || theirs.Count <= leafSize || theirs.Lo == theirs.Hi:
+				resp.Replies[i].Items = summary.Items(theirs.Lo, theirs.Hi)
+			default:
+				resp.Replies[i].Split = true
+			}
+		}
+		if err := enc.Encode(resp); err != nil {
+			return nil, err
+		}
+		rounds++
+	}
+}
+
+// diffItems records the IDs present on only one side of a leaf range.
+func diffItems(local, remote []string, result *Result) {
+	localSet, remoteSet := fromSlice(local), fromSlice(remote)
+	localSet.Difference(remoteSet).ForEach(result.LocalOnly.Add)
+	remoteSet.Difference(localSet).ForEach(result.RemoteOnly.Add)
+}
+
+func fromSlice(items []string) *set.Set[string] {
+	s := set.New[string]()
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
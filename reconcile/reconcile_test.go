@@ -0,0 +1,147 @@
+package reconcile
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// countingConn counts the bytes written through a connection.
+type countingConn struct {
+	io.ReadWriter
+	written int
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.ReadWriter.Write(p)
+	c.written += n
+	return n, err
+}
+
+// reconcile runs both sides over an in-process pipe.
+func reconcile(t *testing.T, a, b *set.Set[string]) (*Result, *Result, int) {
+	t.Helper()
+	left, right := net.Pipe()
+	defer left.Close()
+	defer right.Close()
+
+	type outcome struct {
+		result *Result
+		err    error
+	}
+	done := make(chan outcome)
+	go func() {
+		res, err := Respond(right, b)
+		done <- outcome{res, err}
+	}()
+
+	conn := &countingConn{ReadWriter: left}
+	initiated, err := Initiate(conn, a)
+	if err != nil {
+		t.Fatalf("Initiate: unexpected error: %v", err)
+	}
+	responded := <-done
+	if responded.err != nil {
+		t.Fatalf("Respond: unexpected error: %v", responded.err)
+	}
+	return initiated, responded.result, conn.written
+}
+
+func ids(prefix string, from, to int) *set.Set[string] {
+	s := set.New[string]()
+	for i := from; i < to; i++ {
+		s.Add(fmt.Sprintf("%s-%d", prefix, i))
+	}
+	return s
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *set.Set[string]
+	}{
+		{"BothEmpty", set.New[string](), set.New[string]()},
+		{"Identical", ids("obj", 0, 1000), ids("obj", 0, 1000)},
+		{"OneEmpty", ids("obj", 0, 50), set.New[string]()},
+		{"SmallOverlap", ids("obj", 0, 30), ids("obj", 20, 40)},
+		{"LargeFewDiffs", ids("obj", 0, 50000).Union(ids("a", 0, 5)), ids("obj", 0, 50000).Union(ids("b", 0, 7))},
+	}
+	for _, tt := range tests {
+		initiated, responded, _ := reconcile(t, tt.a, tt.b)
+		if !initiated.LocalOnly.Equal(tt.a.Difference(tt.b)) {
+			t.Errorf("%s: initiator LocalOnly = %v", tt.name, initiated.LocalOnly.ToSlice())
+		}
+		if !initiated.RemoteOnly.Equal(tt.b.Difference(tt.a)) {
+			t.Errorf("%s: initiator RemoteOnly = %v", tt.name, initiated.RemoteOnly.ToSlice())
+		}
+		if !responded.LocalOnly.Equal(initiated.RemoteOnly) || !responded.RemoteOnly.Equal(initiated.LocalOnly) {
+			t.Errorf("%s: responder result does not mirror the initiator", tt.name)
+		}
+		if responded.Rounds != initiated.Rounds {
+			t.Errorf("%s: rounds differ: %d and %d", tt.name, initiated.Rounds, responded.Rounds)
+		}
+	}
+}
+
+func TestReconcileTrafficIsSmall(t *testing.T) {
+	base := ids("obj", 0, 100000)
+	a := base.Union(ids("a", 0, 3))
+	b := base.Union(ids("b", 0, 3))
+	initiated, _, written := reconcile(t, a, b)
+	if initiated.LocalOnly.Size() != 3 || initiated.RemoteOnly.Size() != 3 {
+		t.Fatalf("Expected 3 differences each way, got %d and %d",
+			initiated.LocalOnly.Size(), initiated.RemoteOnly.Size())
+	}
+	// Shipping the IDs alone would take over a megabyte.
+	if written > 100000 {
+		t.Errorf("Expected initiator to send well under 100KB, sent %d bytes", written)
+	}
+	if initiated.Rounds > 8 {
+		t.Errorf("Expected a logarithmic number of rounds, got %d", initiated.Rounds)
+	}
+}
+
+func TestSummaryFingerprint(t *testing.T) {
+	a := Summarize(ids("obj", 0, 100))
+	b := Summarize(ids("obj", 0, 100))
+	c := Summarize(ids("obj", 1, 101))
+	if !a.Fingerprint(0, 1<<63).Matches(b.Fingerprint(0, 1<<63)) {
+		t.Errorf("Expected equal sets to have matching fingerprints")
+	}
+	if a.Fingerprint(0, ^uint64(0)).Matches(c.Fingerprint(0, ^uint64(0))) {
+		t.Errorf("Expected different sets to have different fingerprints")
+	}
+	if total := a.Fingerprint(0, ^uint64(0)).Count; total != 100 {
+		t.Errorf("Expected a count of 100, got %d", total)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		lo, hi uint64
+		parts  int
+	}{
+		{0, ^uint64(0), 16},
+		{0, 3, 4},
+		{10, 10, 1},
+		{0, 100, 15},
+	}
+	for _, tt := range tests {
+		parts := split(tt.lo, tt.hi, fanout)
+		if len(parts) != tt.parts {
+			t.Errorf("split(%d, %d): expected %d parts, got %d", tt.lo, tt.hi, tt.parts, len(parts))
+			continue
+		}
+		if parts[0][0] != tt.lo || parts[len(parts)-1][1] != tt.hi {
+			t.Errorf("split(%d, %d): parts do not cover the range: %v", tt.lo, tt.hi, parts)
+		}
+		for i := 1; i < len(parts); i++ {
+			if parts[i][0] != parts[i-1][1]+1 {
+				t.Errorf("split(%d, %d): parts are not contiguous: %v", tt.lo, tt.hi, parts)
+			}
+		}
+	}
+}
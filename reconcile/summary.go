@@ -0,0 +1,115 @@
+package reconcile
+
+import (
+	"sort"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// Fingerprint summarises the elements whose hashes fall in the inclusive
+// range [Lo, Hi]. Two sets with equal fingerprints over a range almost
+// certainly hold the same elements there.
+type Fingerprint struct {
+	Lo    uint64 `json:"lo"`
+	Hi    uint64 `json:"hi"`
+	Count int    `json:"count"`
+	Xor   uint64 `json:"xor"`
+	Sum   uint64 `json:"sum"`
+}
+
+// Matches reports whether two fingerprints cover the same range with the
+// same contents.
+func (f Fingerprint) Matches(other Fingerprint) bool {
+	return f == other
+}
+
+// Summary is an immutable hash-ordered snapshot of a set of IDs that
+// answers range fingerprint queries in O(log n). Prefix XORs and sums over
+// the sorted hashes play the role of the inner nodes of a Merkle tree.
+type Summary struct {
+	hashes []uint64
+	ids    []string
+	xors   []uint64 // xors[i] is the XOR of hashes[:i]
+	sums   []uint64 // sums[i] is the wrapping sum of mixed hashes[:i]
+}
+
+// Summarize builds a Summary of the current contents of s.
+func Summarize(s *set.Set[string]) *Summary {
+	ids := s.ToSlice()
+	hashes := make([]uint64, len(ids))
+	for i, id := range ids {
+		hashes[i] = hasher.String(id)
+	}
+	sort.Sort(byHash{hashes, ids})
+
+	sum := &Summary{
+		hashes: hashes,
+		ids:    ids,
+		xors:   make([]uint64, len(ids)+1),
+		sums:   make([]uint64, len(ids)+1),
+	}
+	for i, h := range hashes {
+		sum.xors[i+1] = sum.xors[i] ^ h
+		sum.sums[i+1] = sum.sums[i] + hasher.Mix(h)
+	}
+	return sum
+}
+
+// Len returns the number of IDs in the summary.
+func (s *Summary) Len() int {
+	return len(s.ids)
+}
+
+// bounds returns the index range of hashes in [lo, hi].
+func (s *Summary) bounds(lo, hi uint64) (int, int) {
+	i := sort.Search(len(s.hashes), func(i int) bool { return s.hashes[i] >= lo })
+	j := sort.Search(len(s.hashes), func(j int) bool { return s.hashes[j] > hi })
+	return i, j
+}
+
+// Fingerprint returns the fingerprint of the IDs hashing into [lo, hi].
+func (s *Summary) Fingerprint(lo, hi uint64) Fingerprint {
+	i, j := s.bounds(lo, hi)
+	return Fingerprint{
+		Lo:    lo,
+		Hi:    hi,
+		Count: j - i,
+		Xor:   s.xors[j] ^ s.xors[i],
+		Sum:   s.sums[j] - s.sums[i],
+	}
+}
+
+// Items returns the IDs hashing into [lo, hi].
+func (s *Summary) Items(lo, hi uint64) []string {
+	i, j := s.bounds(lo, hi)
+	return append([]string(nil), s.ids[i:j]...)
+}
+
+// split divides [lo, hi] into up to fanout contiguous subranges.
+func split(lo, hi uint64, fanout uint64) [][2]uint64 {
+	width := hi - lo
+	step := width/fanout + 1
+	parts := make([][2]uint64, 0, fanout)
+	for i := uint64(0); i < fanout && i*step <= width; i++ {
+		start := lo + i*step
+		end := hi
+		if width-i*step >= step {
+			end = start + step - 1
+		}
+		parts = append(parts, [2]uint64{start, end})
+	}
+	return parts
+}
+
+type byHash struct {
+	hashes []uint64
+	ids    []string
+}
+
+func (b byHash) Len() int           { return len(b.hashes) }
+func (b byHash) Less(i, j int) bool { return b.hashes[i] < b.hashes[j] }
+func (b byHash) Swap(i, j int) {
+	b.hashes[i], b.hashes[j] = b.hashes[j], b.hashes[i]
+	b.ids[i], b.ids[j] = b.ids[j], b.ids[i]
+}
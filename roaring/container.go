@@ -0,0 +1,541 @@
+package roaring
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// arrayMaxSize is the largest cardinality stored in an array container.
+	// Past this point a bitmap container (8 KiB) is never larger.
+	arrayMaxSize = 4096
+	// bitmapWords is the number of 64-bit words in a bitmap container.
+	bitmapWords = 1 << 16 / 64
+)
+
+// container holds the low 16 bits of every value sharing the same high bits.
+// Mutating methods return the container that should replace the receiver,
+// which lets a container switch representation when it crosses a threshold.
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	forEach(f func(uint16))
+	clone() container
+	toBitmap() *bitmapContainer
+	numRuns() int
+	minimum() uint16
+	maximum() uint16
+}
+
+// arrayContainer stores up to arrayMaxSize values as a sorted slice.
+type arrayContainer struct {
+	content []uint16
+}
+
+func (a *arrayContainer) search(x uint16) (int, bool) {
+	i := sort.Search(len(a.content), func(i int) bool { return a.content[i] >= x })
+	return i, i < len(a.content) && a.content[i] == x
+}
+
+func (a *arrayContainer) add(x uint16) container {
+	i, found := a.search(x)
+	if found {
+		return a
+	}
+	if len(a.content) >= arrayMaxSize {
+		b := a.toBitmap()
+		return b.add(x)
+	}
+	a.content = append(a.content, 0)
+	copy(a.content[i+1:], a.content[i:])
+	a.content[i] = x
+	return a
+}
+
+func (a *arrayContainer) remove(x uint16) container {
+	if i, found := a.search(x); found {
+		a.content = append(a.content[:i], a.content[i+1:]...)
+	}
+	return a
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	_, found := a.search(x)
+	return found
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.content)
+}
+
+func (a *arrayContainer) forEach(f func(uint16)) {
+	for _, v := range a.content {
+		f(v)
+	}
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{content: append([]uint16(nil), a.content...)}
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, v := range a.content {
+		b.words[v>>6] |= 1 << (v & 63)
+	}
+	b.card = len(a.content)
+	return b
+}
+
+func (a *arrayContainer) numRuns() int {
+	runs := 0
+	for i, v := range a.content {
+		if i == 0 || a.content[i-1]+1 != v {
+			runs++
+		}
+	}
+	return runs
+}
+
+func (a *arrayContainer) minimum() uint16 { return a.content[0] }
+
+func (a *arrayContainer) maximum() uint16 { return a.content[len(a.content)-1] }
+
+// bitmapContainer stores values as a fixed 65536-bit bitmap.
+type bitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{words: make([]uint64, bitmapWords)}
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	w, mask := x>>6, uint64(1)<<(x&63)
+	if b.words[w]&mask == 0 {
+		b.words[w] |= mask
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	w, mask := x>>6, uint64(1)<<(x&63)
+	if b.words[w]&mask != 0 {
+		b.words[w] &^= mask
+		b.card--
+		if b.card <= arrayMaxSize {
+			return b.toArray()
+		}
+	}
+	return b
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x>>6]&(1<<(x&63)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) forEach(f func(uint16)) {
+	for i, w := range b.words {
+		for w != 0 {
+			f(uint16(i*64 + bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+}
+
+func (b *bitmapContainer) clone() container {
+	return &bitmapContainer{words: append([]uint64(nil), b.words...), card: b.card}
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	return b
+}
+
+func (b *bitmapContainer) toArray() *arrayContainer {
+	content := make([]uint16, 0, b.card)
+	b.forEach(func(v uint16) { content = append(content, v) })
+	return &arrayContainer{content: content}
+}
+
+func (b *bitmapContainer) numRuns() int {
+	runs := 0
+	var prev uint64
+	for _, w := range b.words {
+		// A run starts at every set bit whose lower neighbour is clear.
+		runs += bits.OnesCount64(w &^ (w<<1 | prev>>63))
+		prev = w
+	}
+	return runs
+}
+
+func (b *bitmapContainer) minimum() uint16 {
+	for i, w := range b.words {
+		if w != 0 {
+			return uint16(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+	return 0
+}
+
+func (b *bitmapContainer) maximum() uint16 {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if w := b.words[i]; w != 0 {
+			return uint16(i*64 + 63 - bits.LeadingZeros64(w))
+		}
+	}
+	return 0
+}
+
+// normalize picks the array or bitmap representation from the cardinality.
+func (b *bitmapContainer) normalize() container {
+	if b.card <= arrayMaxSize {
+		return b.toArray()
+	}
+	return b
+}
+
+// interval16 is an inclusive run of consecutive values.
+type interval16 struct {
+	start, last uint16
+}
+
+// runContainer stores values as sorted, non-adjacent inclusive runs.
+type runContainer struct {
+	runs []interval16
+}
+
+// search returns the index of the first run starting after x.
+func (r *runContainer) search(x uint16) int {
+	return sort.Search(len(r.runs), func(i int) bool { return r.runs[i].start > x })
+}
+
+func (r *runContainer) add(x uint16) container {
+	i := r.search(x)
+	prev := i - 1
+	if prev >= 0 && x <= r.runs[prev].last {
+		return r
+	}
+	joinPrev := prev >= 0 && int(r.runs[prev].last)+1 == int(x)
+	joinNext := i < len(r.runs) && int(x)+1 == int(r.runs[i].start)
+	switch {
+	case joinPrev && joinNext:
+		r.runs[prev].last = r.runs[i].last
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case joinPrev:
+		r.runs[prev].last = x
+	case joinNext:
+		r.runs[i].start = x
+	default:
+		r.runs = append(r.runs, interval16{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i] = interval16{start: x, last: x}
+	}
+	return r
+}
+
+func (r *runContainer) remove(x uint16) container {
+	i := r.search(x) - 1
+	if i < 0 || x > r.runs[i].last {
+		return r
+	}
+	run := r.runs[i]
+	switch {
+	case run.start == run.last:
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case x == run.start:
+		r.runs[i].start++
+	case x == run.last:
+		r.runs[i].last--
+	default:
+		r.runs = append(r.runs, interval16{})
+		copy(r.runs[i+2:], r.runs[i+1:])
+		r.runs[i] = interval16{start: run.start, last: x - 1}
+		r.runs[i+1] = interval16{start: x + 1, last: run.last}
+	}
+	if len(r.runs) == 0 {
+		return &arrayContainer{}
+	}
+	return r
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := r.search(x) - 1
+	return i >= 0 && x <= r.runs[i].last
+}
+
+func (r *runContainer) cardinality() int {
+	card := 0
+	for _, run := range r.runs {
+		card += int(run.last-run.start) + 1
+	}
+	return card
+}
+
+func (r *runContainer) forEach(f func(uint16)) {
+	for _, run := range r.runs {
+		for v := int(run.start); v <= int(run.last); v++ {
+			f(uint16(v))
+		}
+	}
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{runs: append([]interval16(nil), r.runs...)}
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, run := range r.runs {
+		for v := int(run.start); v <= int(run.last); v++ {
+			b.words[v>>6] |= 1 << (v & 63)
+		}
+		b.card += int(run.last-run.start) + 1
+	}
+	return b
+}
+
+func (r *runContainer) numRuns() int {
+	return len(r.runs)
+}
+
+func (r *runContainer) minimum() uint16 { return r.runs[0].start }
+
+func (r *runContainer) maximum() uint16 { return r.runs[len(r.runs)-1].last }
+
+// toRuns converts any container into its run representation.
+func toRuns(c container) *runContainer {
+	if r, ok := c.(*runContainer); ok {
+		return r
+	}
+	r := &runContainer{runs: make([]interval16, 0, c.numRuns())}
+	c.forEach(func(v uint16) {
+		n := len(r.runs)
+		if n > 0 && int(r.runs[n-1].last)+1 == int(v) {
+			r.runs[n-1].last = v
+			return
+		}
+		r.runs = append(r.runs, interval16{start: v, last: v})
+	})
+	return r
+}
+
+// optimize returns the representation of c with the smallest serialized
+// size, choosing between array, bitmap and run containers.
+func optimize(c container) container {
+	card := c.cardinality()
+	runSize := 2 + 4*c.numRuns()
+	plainSize := 8192
+	if card <= arrayMaxSize {
+		plainSize = 2 * card
+	}
+	if runSize < plainSize {
+		return toRuns(c)
+	}
+	if _, ok := c.(*runContainer); ok {
+		return c.toBitmap().normalize()
+	}
+	return c
+}
+
+// operand prepares a container for a binary operation. Run containers are
+// expanded to the plain representation matching their cardinality.
+func operand(c container) container {
+	if _, ok := c.(*runContainer); ok {
+		return c.toBitmap().normalize()
+	}
+	return c
+}
+
+func and(x, y container) container {
+	x, y = operand(x), operand(y)
+	switch a := x.(type) {
+	case *arrayContainer:
+		if b, ok := y.(*arrayContainer); ok {
+			return intersectArrays(a, b)
+		}
+		return filterArray(a, y, true)
+	case *bitmapContainer:
+		if b, ok := y.(*arrayContainer); ok {
+			return filterArray(b, a, true)
+		}
+		b := y.(*bitmapContainer)
+		return combineBitmaps(a, b, func(p, q uint64) uint64 { return p & q })
+	}
+	return nil
+}
+
+func or(x, y container) container {
+	x, y = operand(x), operand(y)
+	a, aArray := x.(*arrayContainer)
+	b, bArray := y.(*arrayContainer)
+	switch {
+	case aArray && bArray:
+		return unionArrays(a, b)
+	case aArray:
+		return orArray(y.(*bitmapContainer), a)
+	case bArray:
+		return orArray(x.(*bitmapContainer), b)
+	}
+	return combineBitmaps(x.(*bitmapContainer), y.(*bitmapContainer), func(p, q uint64) uint64 { return p | q })
+}
+
+func andNot(x, y container) container {
+	x, y = operand(x), operand(y)
+	switch a := x.(type) {
+	case *arrayContainer:
+		return filterArray(a, y, false)
+	case *bitmapContainer:
+		if b, ok := y.(*arrayContainer); ok {
+			res := a.clone().(*bitmapContainer)
+			for _, v := range b.content {
+				w, mask := v>>6, uint64(1)<<(v&63)
+				if res.words[w]&mask != 0 {
+					res.words[w] &^= mask
+					res.card--
+				}
+			}
+			return res.normalize()
+		}
+		b := y.(*bitmapContainer)
+		return combineBitmaps(a, b, func(p, q uint64) uint64 { return p &^ q })
+	}
+	return nil
+}
+
+func xor(x, y container) container {
+	x, y = operand(x), operand(y)
+	a, aArray := x.(*arrayContainer)
+	b, bArray := y.(*arrayContainer)
+	switch {
+	case aArray && bArray:
+		return xorArrays(a, b)
+	case aArray:
+		return xorArray(y.(*bitmapContainer), a)
+	case bArray:
+		return xorArray(x.(*bitmapContainer), b)
+	}
+	return combineBitmaps(x.(*bitmapContainer), y.(*bitmapContainer), func(p, q uint64) uint64 { return p ^ q })
+}
+
+// orArray returns a copy of b with every value of a set.
+func orArray(b *bitmapContainer, a *arrayContainer) container {
+	res := b.clone().(*bitmapContainer)
+	for _, v := range a.content {
+		res.add(v)
+	}
+	return res
+}
+
+// xorArray returns a copy of b with every value of a flipped.
+func xorArray(b *bitmapContainer, a *arrayContainer) container {
+	res := b.clone().(*bitmapContainer)
+	for _, v := range a.content {
+		w, mask := v>>6, uint64(1)<<(v&63)
+		if res.words[w]&mask != 0 {
+			res.card--
+		} else {
+			res.card++
+		}
+		res.words[w] ^= mask
+	}
+	return res.normalize()
+}
+
+func combineBitmaps(a, b *bitmapContainer, op func(p, q uint64) uint64) container {
+	res := newBitmapContainer()
+	for i := range res.words {
+		w := op(a.words[i], b.words[i])
+		res.words[i] = w
+		res.card += bits.OnesCount64(w)
+	}
+	return res.normalize()
+}
+
+// filterArray keeps the values of a that are (keep=true) or are not
+// (keep=false) present in c.
+func filterArray(a *arrayContainer, c container, keep bool) container {
+	content := make([]uint16, 0, len(a.content))
+	for _, v := range a.content {
+		if c.contains(v) == keep {
+			content = append(content, v)
+		}
+	}
+	return &arrayContainer{content: content}
+}
+
+func intersectArrays(a, b *arrayContainer) container {
+	content := make([]uint16, 0, len(a.content))
+	i, j := 0, 0
+	for i < len(a.content) && j < len(b.content) {
+		switch {
+		case a.content[i] < b.content[j]:
+			i++
+		case a.content[i] > b.content[j]:
+			j++
+		default:
+			content = append(content, a.content[i])
+			i++
+			j++
+		}
+	}
+	return &arrayContainer{content: content}
+}
+
+func unionArrays(a, b *arrayContainer) container {
+	content := make([]uint16, 0, len(a.content)+len(b.content))
+	i, j := 0, 0
+	for i < len(a.content) && j < len(b.content) {
+		switch {
+		case a.content[i] < b.content[j]:
+			content = append(content, a.content[i])
+			i++
+		case a.content[i] > b.content[j]:
+			content = append(content, b.content[j])
+			j++
+		default:
+			content = append(content, a.content[i])
+			i++
+			j++
+		}
+	}
+	content = append(content, a.content[i:]...)
+	content = append(content, b.content[j:]...)
+	res := &arrayContainer{content: content}
+	if len(content) > arrayMaxSize {
+		return res.toBitmap()
+	}
+	return res
+}
+
+func xorArrays(a, b *arrayContainer) container {
+	content := make([]uint16, 0, len(a.content)+len(b.content))
+	i, j := 0, 0
+	for i < len(a.content) && j < len(b.content) {
+		switch {
+		case a.content[i] < b.content[j]:
+			content = append(content, a.content[i])
+			i++
+		case a.content[i] > b.content[j]:
+			content = append(content, b.content[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	content = append(content, a.content[i:]...)
+	content = append(content, b.content[j:]...)
+	res := &arrayContainer{content: content}
+	if len(content) > arrayMaxSize {
+		return res.toBitmap()
+	}
+	return res
+}
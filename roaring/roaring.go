@@ -0,0 +1,323 @@
+// Package roaring implements Roaring compressed bitmaps for sets of 32-bit
+// and 64-bit unsigned integers.
+//
+// A Bitmap splits each value into its high and low 16 bits. Values sharing
+// the same high bits are stored together in a container, which is either a
+// sorted array, a 65536-bit bitmap or a list of runs, whichever suits the
+// data. Bitmaps are not safe for concurrent use; callers sharing a Bitmap
+// between goroutines must synchronise access themselves.
+package roaring
+
+import "sort"
+
+// Bitmap is a compressed set of uint32 values.
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+// New creates and returns a new, empty Bitmap.
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// Of creates a Bitmap holding the given values.
+func Of(values ...uint32) *Bitmap {
+	b := New()
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+func highBits(x uint32) uint16 { return uint16(x >> 16) }
+
+func lowBits(x uint32) uint16 { return uint16(x) }
+
+// index returns the position of key in b.keys, or where it would be inserted.
+func (b *Bitmap) index(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+// Add inserts a value into the bitmap.
+func (b *Bitmap) Add(x uint32) {
+	key := highBits(x)
+	i, found := b.index(key)
+	if found {
+		b.containers[i] = b.containers[i].add(lowBits(x))
+		return
+	}
+	b.insertAt(i, key, &arrayContainer{content: []uint16{lowBits(x)}})
+}
+
+// AddRange inserts every value in the half-open range [start, end).
+// Ranges are stored as run containers, so large ranges stay compact.
+func (b *Bitmap) AddRange(start, end uint64) {
+	if end > 1<<32 {
+		end = 1 << 32
+	}
+	for start < end {
+		key := uint16(start >> 16)
+		last := end - 1
+		if last>>16 != start>>16 {
+			last = start | 0xFFFF
+		}
+		run := &runContainer{runs: []interval16{{start: uint16(start), last: uint16(last)}}}
+		if i, found := b.index(key); found {
+			b.containers[i] = optimize(or(b.containers[i], run))
+		} else {
+			b.insertAt(i, key, optimize(run))
+		}
+		start = last + 1
+	}
+}
+
+// Remove deletes a value from the bitmap.
+func (b *Bitmap) Remove(x uint32) {
+	i, found := b.index(highBits(x))
+	if !found {
+		return
+	}
+	c := b.containers[i].remove(lowBits(x))
+	if c.cardinality() == 0 {
+		b.removeAt(i)
+		return
+	}
+	b.containers[i] = c
+}
+
+// Contains checks if a value is in the bitmap.
+func (b *Bitmap) Contains(x uint32) bool {
+	i, found := b.index(highBits(x))
+	return found && b.containers[i].contains(lowBits(x))
+}
+
+// Cardinality returns the number of values in the bitmap without
+// materialising them.
+func (b *Bitmap) Cardinality() uint64 {
+	var card uint64
+	for _, c := range b.containers {
+		card += uint64(c.cardinality())
+	}
+	return card
+}
+
+// IsEmpty reports whether the bitmap holds no values.
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.keys) == 0
+}
+
+// Minimum returns the smallest value in the bitmap, or false if it is empty.
+func (b *Bitmap) Minimum() (uint32, bool) {
+	if b.IsEmpty() {
+		return 0, false
+	}
+	return uint32(b.keys[0])<<16 | uint32(b.containers[0].minimum()), true
+}
+
+// Maximum returns the largest value in the bitmap, or false if it is empty.
+func (b *Bitmap) Maximum() (uint32, bool) {
+	if b.IsEmpty() {
+		return 0, false
+	}
+	last := len(b.keys) - 1
+	return uint32(b.keys[last])<<16 | uint32(b.containers[last].maximum()), true
+}
+
+// Clear removes every value from the bitmap.
+func (b *Bitmap) Clear() {
+	b.keys = nil
+	b.containers = nil
+}
+
+// Clone returns a deep copy of the bitmap.
+func (b *Bitmap) Clone() *Bitmap {
+	clone := &Bitmap{
+		keys:       append([]uint16(nil), b.keys...),
+		containers: make([]container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		clone.containers[i] = c.clone()
+	}
+	return clone
+}
+
+// Equal checks if both bitmaps hold exactly the same values.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if len(b.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range b.keys {
+		if other.keys[i] != key || b.containers[i].cardinality() != other.containers[i].cardinality() {
+			return false
+		}
+		if and(b.containers[i], other.containers[i]).cardinality() != b.containers[i].cardinality() {
+			return false
+		}
+	}
+	return true
+}
+
+// RunOptimize converts every container to the representation with the
+// smallest serialized size, using run containers for long stretches of
+// consecutive values.
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// ForEach applies the provided function to each value in ascending order.
+func (b *Bitmap) ForEach(f func(uint32)) {
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		c.forEach(func(low uint16) {
+			f(high | uint32(low))
+		})
+	}
+}
+
+// Iterator returns a channel yielding the values in ascending order.
+func (b *Bitmap) Iterator() <-chan uint32 {
+	ch := make(chan uint32)
+	go func() {
+		b.ForEach(func(v uint32) {
+			ch <- v
+		})
+		close(ch)
+	}()
+	return ch
+}
+
+// ToSlice returns the values of the bitmap as a sorted slice.
+func (b *Bitmap) ToSlice() []uint32 {
+	slice := make([]uint32, 0, b.Cardinality())
+	b.ForEach(func(v uint32) {
+		slice = append(slice, v)
+	})
+	return slice
+}
+
+// And returns a new bitmap that is the intersection of b and another bitmap.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			result.appendNonEmpty(b.keys[i], and(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Or returns a new bitmap that is the union of b and another bitmap.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			result.appendNonEmpty(b.keys[i], b.containers[i].clone())
+			i++
+		case i == len(b.keys) || b.keys[i] > other.keys[j]:
+			result.appendNonEmpty(other.keys[j], other.containers[j].clone())
+			j++
+		default:
+			result.appendNonEmpty(b.keys[i], or(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// AndNot returns a new bitmap holding the values of b that are not in other.
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) {
+		switch {
+		case j == len(other.keys) || b.keys[i] < other.keys[j]:
+			result.appendNonEmpty(b.keys[i], b.containers[i].clone())
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			result.appendNonEmpty(b.keys[i], andNot(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Xor returns a new bitmap holding the values in either bitmap but not both.
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			result.appendNonEmpty(b.keys[i], b.containers[i].clone())
+			i++
+		case i == len(b.keys) || b.keys[i] > other.keys[j]:
+			result.appendNonEmpty(other.keys[j], other.containers[j].clone())
+			j++
+		default:
+			result.appendNonEmpty(b.keys[i], xor(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// AndCardinality returns the size of the intersection of b and other
+// without building the intersection bitmap.
+func (b *Bitmap) AndCardinality(other *Bitmap) uint64 {
+	var card uint64
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			card += uint64(and(b.containers[i], other.containers[j]).cardinality())
+			i++
+			j++
+		}
+	}
+	return card
+}
+
+func (b *Bitmap) appendNonEmpty(key uint16, c container) {
+	if c.cardinality() == 0 {
+		return
+	}
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
+
+func (b *Bitmap) insertAt(i int, key uint16, c container) {
+	b.keys = append(b.keys, 0)
+	copy(b.keys[i+1:], b.keys[i:])
+	b.keys[i] = key
+	b.containers = append(b.containers, nil)
+	copy(b.containers[i+1:], b.containers[i:])
+	b.containers[i] = c
+}
+
+func (b *Bitmap) removeAt(i int) {
+	b.keys = append(b.keys[:i], b.keys[i+1:]...)
+	b.containers = append(b.containers[:i], b.containers[i+1:]...)
+}
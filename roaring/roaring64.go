@@ -0,0 +1,285 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Bitmap64 is a compressed set of uint64 values. It keeps one 32-bit Bitmap
+// per distinct value of the high 32 bits, so sparse 64-bit ID spaces cost
+// no more than the equivalent 32-bit ones.
+type Bitmap64 struct {
+	keys    []uint32
+	bitmaps []*Bitmap
+}
+
+// New64 creates and returns a new, empty Bitmap64.
+func New64() *Bitmap64 {
+	return &Bitmap64{}
+}
+
+// Of64 creates a Bitmap64 holding the given values.
+func Of64(values ...uint64) *Bitmap64 {
+	b := New64()
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+func (b *Bitmap64) index(key uint32) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+// Add inserts a value into the bitmap.
+func (b *Bitmap64) Add(x uint64) {
+	key := uint32(x >> 32)
+	i, found := b.index(key)
+	if !found {
+		b.keys = append(b.keys, 0)
+		copy(b.keys[i+1:], b.keys[i:])
+		b.keys[i] = key
+		b.bitmaps = append(b.bitmaps, nil)
+		copy(b.bitmaps[i+1:], b.bitmaps[i:])
+		b.bitmaps[i] = New()
+	}
+	b.bitmaps[i].Add(uint32(x))
+}
+
+// Remove deletes a value from the bitmap.
+func (b *Bitmap64) Remove(x uint64) {
+	i, found := b.index(uint32(x >> 32))
+	if !found {
+		return
+	}
+	b.bitmaps[i].Remove(uint32(x))
+	if b.bitmaps[i].IsEmpty() {
+		b.keys = append(b.keys[:i], b.keys[i+1:]...)
+		b.bitmaps = append(b.bitmaps[:i], b.bitmaps[i+1:]...)
+	}
+}
+
+// Contains checks if a value is in the bitmap.
+func (b *Bitmap64) Contains(x uint64) bool {
+	i, found := b.index(uint32(x >> 32))
+	return found && b.bitmaps[i].Contains(uint32(x))
+}
+
+// Cardinality returns the number of values in the bitmap.
+func (b *Bitmap64) Cardinality() uint64 {
+	var card uint64
+	for _, bm := range b.bitmaps {
+		card += bm.Cardinality()
+	}
+	return card
+}
+
+// IsEmpty reports whether the bitmap holds no values.
+func (b *Bitmap64) IsEmpty() bool {
+	return len(b.keys) == 0
+}
+
+// Clone returns a deep copy of the bitmap.
+func (b *Bitmap64) Clone() *Bitmap64 {
+	clone := &Bitmap64{
+		keys:    append([]uint32(nil), b.keys...),
+		bitmaps: make([]*Bitmap, len(b.bitmaps)),
+	}
+	for i, bm := range b.bitmaps {
+		clone.bitmaps[i] = bm.Clone()
+	}
+	return clone
+}
+
+// Equal checks if both bitmaps hold exactly the same values.
+func (b *Bitmap64) Equal(other *Bitmap64) bool {
+	if len(b.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range b.keys {
+		if other.keys[i] != key || !b.bitmaps[i].Equal(other.bitmaps[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RunOptimize applies Bitmap.RunOptimize to every underlying bitmap.
+func (b *Bitmap64) RunOptimize() {
+	for _, bm := range b.bitmaps {
+		bm.RunOptimize()
+	}
+}
+
+// ForEach applies the provided function to each value in ascending order.
+func (b *Bitmap64) ForEach(f func(uint64)) {
+	for i, bm := range b.bitmaps {
+		high := uint64(b.keys[i]) << 32
+		bm.ForEach(func(low uint32) {
+			f(high | uint64(low))
+		})
+	}
+}
+
+// Iterator returns a channel yielding the values in ascending order.
+func (b *Bitmap64) Iterator() <-chan uint64 {
+	ch := make(chan uint64)
+	go func() {
+		b.ForEach(func(v uint64) {
+			ch <- v
+		})
+		close(ch)
+	}()
+	return ch
+}
+
+// ToSlice returns the values of the bitmap as a sorted slice.
+func (b *Bitmap64) ToSlice() []uint64 {
+	slice := make([]uint64, 0, b.Cardinality())
+	b.ForEach(func(v uint64) {
+		slice = append(slice, v)
+	})
+	return slice
+}
+
+// And returns a new bitmap that is the intersection of b and another bitmap.
+func (b *Bitmap64) And(other *Bitmap64) *Bitmap64 {
+	return b.combine(other, (*Bitmap).And, false, false)
+}
+
+// Or returns a new bitmap that is the union of b and another bitmap.
+func (b *Bitmap64) Or(other *Bitmap64) *Bitmap64 {
+	return b.combine(other, (*Bitmap).Or, true, true)
+}
+
+// AndNot returns a new bitmap holding the values of b that are not in other.
+func (b *Bitmap64) AndNot(other *Bitmap64) *Bitmap64 {
+	return b.combine(other, (*Bitmap).AndNot, true, false)
+}
+
+// Xor returns a new bitmap holding the values in either bitmap but not both.
+func (b *Bitmap64) Xor(other *Bitmap64) *Bitmap64 {
+	return b.combine(other, (*Bitmap).Xor, true, true)
+}
+
+// combine merges the key lists of both bitmaps, applying op where keys
+// match and copying unmatched bitmaps from b (keepLeft) or other (keepRight).
+func (b *Bitmap64) combine(other *Bitmap64, op func(*Bitmap, *Bitmap) *Bitmap, keepLeft, keepRight bool) *Bitmap64 {
+	result := New64()
+	add := func(key uint32, bm *Bitmap) {
+		if !bm.IsEmpty() {
+			result.keys = append(result.keys, key)
+			result.bitmaps = append(result.bitmaps, bm)
+		}
+	}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			if keepLeft {
+				add(b.keys[i], b.bitmaps[i].Clone())
+			}
+			i++
+		case i == len(b.keys) || b.keys[i] > other.keys[j]:
+			if keepRight {
+				add(other.keys[j], other.bitmaps[j].Clone())
+			}
+			j++
+		default:
+			add(b.keys[i], op(b.bitmaps[i], other.bitmaps[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// WriteTo writes the bitmap to w in the portable 64-bit Roaring format: a
+// 64-bit count followed by each 32-bit high key and its 32-bit bitmap.
+func (b *Bitmap64) WriteTo(w io.Writer) (int64, error) {
+	var scratch [8]byte
+	binary.LittleEndian.PutUint64(scratch[:], uint64(len(b.keys)))
+	m, err := w.Write(scratch[:8])
+	total := int64(m)
+	if err != nil {
+		return total, err
+	}
+	for i, bm := range b.bitmaps {
+		binary.LittleEndian.PutUint32(scratch[:], b.keys[i])
+		m, err := w.Write(scratch[:4])
+		total += int64(m)
+		if err != nil {
+			return total, err
+		}
+		n, err := bm.WriteTo(w)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// ReadFrom replaces the contents of the bitmap with a portable 64-bit
+// Roaring serialization read from r.
+func (b *Bitmap64) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	count, err := cr.uint64()
+	if err != nil {
+		return cr.n, wrapEOF(err)
+	}
+	if count > 1<<32 {
+		return cr.n, fmt.Errorf("%w: %d bitmaps", ErrInvalidFormat, count)
+	}
+	keys := make([]uint32, 0, min64(count, 1024))
+	bitmaps := make([]*Bitmap, 0, min64(count, 1024))
+	for k := uint64(0); k < count; k++ {
+		key, err := cr.uint32()
+		if err != nil {
+			return cr.n, wrapEOF(err)
+		}
+		if len(keys) > 0 && key <= keys[len(keys)-1] {
+			return cr.n, fmt.Errorf("%w: keys out of order", ErrInvalidFormat)
+		}
+		bm := New()
+		if err := bm.decode(cr); err != nil {
+			return cr.n, wrapEOF(err)
+		}
+		// Other implementations may write empty bitmaps, but Bitmap64
+		// never keeps one.
+		if bm.IsEmpty() {
+			continue
+		}
+		keys = append(keys, key)
+		bitmaps = append(bitmaps, bm)
+	}
+	b.keys = keys
+	b.bitmaps = bitmaps
+	return cr.n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (b *Bitmap64) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bitmap64) UnmarshalBinary(data []byte) error {
+	_, err := b.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+func min64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package roaring
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBitmap64(t *testing.T) {
+	b := Of64(1<<40, 3, 1<<40+7, 1<<63)
+	if !b.Contains(1<<40+7) || b.Contains(1<<40+8) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+	if card := b.Cardinality(); card != 4 {
+		t.Errorf("Expected cardinality 4, got %d", card)
+	}
+	expected := []uint64{3, 1 << 40, 1<<40 + 7, 1 << 63}
+	if !reflect.DeepEqual(b.ToSlice(), expected) {
+		t.Errorf("Expected %v, got %v", expected, b.ToSlice())
+	}
+	b.Remove(3)
+	if b.Contains(3) || len(b.keys) != 2 {
+		t.Errorf("Expected Remove to drop the empty high key, keys: %v", b.keys)
+	}
+}
+
+func TestBitmap64Operations(t *testing.T) {
+	a := Of64(1, 2, 1<<40, 1<<40+1)
+	b := Of64(2, 3, 1<<40+1, 1<<50)
+
+	tests := []struct {
+		name     string
+		result   *Bitmap64
+		expected []uint64
+	}{
+		{"And", a.And(b), []uint64{2, 1<<40 + 1}},
+		{"Or", a.Or(b), []uint64{1, 2, 3, 1 << 40, 1<<40 + 1, 1 << 50}},
+		{"AndNot", a.AndNot(b), []uint64{1, 1 << 40}},
+		{"Xor", a.Xor(b), []uint64{1, 3, 1 << 40, 1 << 50}},
+	}
+	for _, tt := range tests {
+		if got := tt.result.ToSlice(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestBitmap64Serialization(t *testing.T) {
+	b := Of64(1, 1<<40, 1<<63)
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := buf.Bytes()[0]; count != 3 {
+		t.Errorf("Expected a leading count of 3, got %d", count)
+	}
+
+	decoded := New64()
+	if _, err := decoded.ReadFrom(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Equal(b) {
+		t.Errorf("Expected %v, got %v", b.ToSlice(), decoded.ToSlice())
+	}
+}
+
+func TestBitmap64ReadFromDropsEmptyBitmaps(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write([]byte{2, 0, 0, 0, 0, 0, 0, 0})
+	// An empty 32-bit bitmap under key 0.
+	buf.Write([]byte{0, 0, 0, 0, 0x3a, 0x30, 0, 0, 0, 0, 0, 0})
+	buf.Write([]byte{1, 0, 0, 0})
+	Of(7).WriteTo(&buf)
+
+	decoded := New64()
+	if _, err := decoded.ReadFrom(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(decoded.keys) != 1 || !decoded.Equal(Of64(1<<32|7)) {
+		t.Errorf("Expected only the non-empty bitmap, got keys %v and values %v", decoded.keys, decoded.ToSlice())
+	}
+}
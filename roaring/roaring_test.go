@@ -0,0 +1,207 @@
+package roaring
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// reference builds the expected result of an operation from plain maps.
+func reference(a, b map[uint32]bool, keep func(inA, inB bool) bool) []uint32 {
+	all := map[uint32]bool{}
+	for v := range a {
+		all[v] = true
+	}
+	for v := range b {
+		all[v] = true
+	}
+	result := []uint32{}
+	for v := range all {
+		if keep(a[v], b[v]) {
+			result = append(result, v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// randomBitmap mixes sparse, dense and run-shaped containers.
+func randomBitmap(rng *rand.Rand) (*Bitmap, map[uint32]bool) {
+	b := New()
+	m := map[uint32]bool{}
+	for i := 0; i < 2000; i++ {
+		v := rng.Uint32() % (8 << 16)
+		b.Add(v)
+		m[v] = true
+	}
+	for i := 0; i < 6000; i++ {
+		v := 3<<16 + rng.Uint32()%(1<<15)
+		b.Add(v)
+		m[v] = true
+	}
+	start := uint64(5<<16 + rng.Intn(1000))
+	b.AddRange(start, start+20000)
+	for v := start; v < start+20000; v++ {
+		m[uint32(v)] = true
+	}
+	return b, m
+}
+
+func TestAddContainsRemove(t *testing.T) {
+	b := New()
+	b.Add(1)
+	b.Add(1 << 20)
+	b.Add(1)
+	if !b.Contains(1) || !b.Contains(1<<20) {
+		t.Errorf("Expected bitmap to contain 1 and 1<<20")
+	}
+	if b.Contains(2) {
+		t.Errorf("Expected bitmap to not contain 2")
+	}
+	if card := b.Cardinality(); card != 2 {
+		t.Errorf("Expected cardinality 2, got %d", card)
+	}
+	b.Remove(1)
+	b.Remove(1 << 20)
+	if !b.IsEmpty() {
+		t.Errorf("Expected bitmap to be empty, got %v", b.ToSlice())
+	}
+}
+
+func TestContainerConversion(t *testing.T) {
+	b := New()
+	for i := uint32(0); i <= arrayMaxSize; i++ {
+		b.Add(i * 2)
+	}
+	if _, ok := b.containers[0].(*bitmapContainer); !ok {
+		t.Fatalf("Expected bitmap container after %d values, got %T", arrayMaxSize+1, b.containers[0])
+	}
+	b.Remove(0)
+	if _, ok := b.containers[0].(*arrayContainer); !ok {
+		t.Fatalf("Expected array container after removal, got %T", b.containers[0])
+	}
+
+	b = New()
+	b.AddRange(0, 1<<16)
+	if _, ok := b.containers[0].(*runContainer); !ok {
+		t.Fatalf("Expected run container for a full range, got %T", b.containers[0])
+	}
+	b.Remove(100)
+	if b.Contains(100) || !b.Contains(99) || !b.Contains(101) {
+		t.Errorf("Remove inside a run did not split it correctly")
+	}
+	if card := b.Cardinality(); card != 1<<16-1 {
+		t.Errorf("Expected cardinality %d, got %d", 1<<16-1, card)
+	}
+	b.Add(100)
+	if n := b.containers[0].numRuns(); n != 1 {
+		t.Errorf("Expected runs to merge back into one, got %d", n)
+	}
+}
+
+func TestRunOptimize(t *testing.T) {
+	b := New()
+	for i := uint32(0); i < 10000; i++ {
+		b.Add(i)
+	}
+	before := b.SerializedSize()
+	b.RunOptimize()
+	if _, ok := b.containers[0].(*runContainer); !ok {
+		t.Fatalf("Expected run container after RunOptimize, got %T", b.containers[0])
+	}
+	if after := b.SerializedSize(); after >= before {
+		t.Errorf("Expected RunOptimize to shrink the bitmap, %d -> %d bytes", before, after)
+	}
+	if card := b.Cardinality(); card != 10000 {
+		t.Errorf("Expected cardinality 10000, got %d", card)
+	}
+}
+
+func TestAddRange(t *testing.T) {
+	b := New()
+	b.AddRange(65530, 65540)
+	expected := []uint32{65530, 65531, 65532, 65533, 65534, 65535, 65536, 65537, 65538, 65539}
+	if !reflect.DeepEqual(b.ToSlice(), expected) {
+		t.Errorf("Expected %v, got %v", expected, b.ToSlice())
+	}
+	b.AddRange(1<<32-2, 1<<33)
+	if max, _ := b.Maximum(); max != 1<<32-1 {
+		t.Errorf("Expected maximum %d, got %d", uint32(1<<32-1), max)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 5; round++ {
+		a, ma := randomBitmap(rng)
+		b, mb := randomBitmap(rng)
+		if round%2 == 1 {
+			a.RunOptimize()
+		}
+
+		tests := []struct {
+			name   string
+			result *Bitmap
+			keep   func(inA, inB bool) bool
+		}{
+			{"And", a.And(b), func(x, y bool) bool { return x && y }},
+			{"Or", a.Or(b), func(x, y bool) bool { return x || y }},
+			{"AndNot", a.AndNot(b), func(x, y bool) bool { return x && !y }},
+			{"Xor", a.Xor(b), func(x, y bool) bool { return x != y }},
+		}
+		for _, tt := range tests {
+			expected := reference(ma, mb, tt.keep)
+			if got := tt.result.ToSlice(); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("%s: expected %d values, got %d", tt.name, len(expected), len(got))
+			}
+			if card := tt.result.Cardinality(); card != uint64(len(expected)) {
+				t.Fatalf("%s: expected cardinality %d, got %d", tt.name, len(expected), card)
+			}
+		}
+		if card := a.AndCardinality(b); card != a.And(b).Cardinality() {
+			t.Fatalf("AndCardinality: expected %d, got %d", a.And(b).Cardinality(), card)
+		}
+	}
+}
+
+func TestMinimumMaximum(t *testing.T) {
+	b := New()
+	if _, ok := b.Minimum(); ok {
+		t.Errorf("Expected no minimum for an empty bitmap")
+	}
+	b = Of(70000, 5, 1<<31)
+	if min, _ := b.Minimum(); min != 5 {
+		t.Errorf("Expected minimum 5, got %d", min)
+	}
+	if max, _ := b.Maximum(); max != 1<<31 {
+		t.Errorf("Expected maximum %d, got %d", 1<<31, max)
+	}
+}
+
+func TestCloneAndEqual(t *testing.T) {
+	b := Of(1, 2, 3, 1<<20)
+	clone := b.Clone()
+	if !b.Equal(clone) {
+		t.Errorf("Expected clone to equal original")
+	}
+	clone.Add(4)
+	if b.Contains(4) {
+		t.Errorf("Expected clone to be independent of the original")
+	}
+	if b.Equal(clone) {
+		t.Errorf("Expected bitmaps to differ after modifying the clone")
+	}
+}
+
+func TestIterator(t *testing.T) {
+	b := Of(1<<20, 3, 1, 2)
+	result := []uint32{}
+	for v := range b.Iterator() {
+		result = append(result, v)
+	}
+	expected := []uint32{1, 2, 3, 1 << 20}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected result %v, got: %v", expected, result)
+	}
+}
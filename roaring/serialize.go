@@ -0,0 +1,296 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Cookies and thresholds from the portable Roaring format specification
+// (https://github.com/RoaringBitmap/RoaringFormatSpec).
+const (
+	serialCookieNoRunContainer = 12346
+	serialCookie               = 12347
+	noOffsetThreshold          = 4
+)
+
+// ErrInvalidFormat is returned when decoding data that is not a valid
+// portable Roaring serialization.
+var ErrInvalidFormat = errors.New("roaring: invalid serialized bitmap")
+
+// SerializedSize returns the number of bytes WriteTo will produce.
+func (b *Bitmap) SerializedSize() int {
+	n := len(b.keys)
+	size := 8 + 8*n
+	if b.hasRuns() {
+		size = 4 + (n+7)/8 + 4*n
+		if n >= noOffsetThreshold {
+			size += 4 * n
+		}
+	}
+	for _, c := range b.containers {
+		size += containerSize(c)
+	}
+	return size
+}
+
+func (b *Bitmap) hasRuns() bool {
+	for _, c := range b.containers {
+		if _, ok := c.(*runContainer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containerSize(c container) int {
+	switch c := c.(type) {
+	case *arrayContainer:
+		return 2 * len(c.content)
+	case *runContainer:
+		return 2 + 4*len(c.runs)
+	default:
+		return 8 * bitmapWords
+	}
+}
+
+// WriteTo writes the bitmap to w in the portable Roaring format, which is
+// understood by the C, Java and Go Roaring implementations.
+func (b *Bitmap) WriteTo(w io.Writer) (int64, error) {
+	n := len(b.keys)
+	hasRuns := b.hasRuns()
+	buf := make([]byte, 0, b.SerializedSize())
+
+	if hasRuns {
+		buf = binary.LittleEndian.AppendUint32(buf, serialCookie|uint32(n-1)<<16)
+		runFlags := make([]byte, (n+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(*runContainer); ok {
+				runFlags[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf = append(buf, runFlags...)
+	} else {
+		buf = binary.LittleEndian.AppendUint32(buf, serialCookieNoRunContainer)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(n))
+	}
+
+	for i, c := range b.containers {
+		buf = binary.LittleEndian.AppendUint16(buf, b.keys[i])
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(c.cardinality()-1))
+	}
+
+	if !hasRuns || n >= noOffsetThreshold {
+		offset := len(buf) + 4*n
+		for _, c := range b.containers {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(offset))
+			offset += containerSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case *arrayContainer:
+			for _, v := range c.content {
+				buf = binary.LittleEndian.AppendUint16(buf, v)
+			}
+		case *runContainer:
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(c.runs)))
+			for _, run := range c.runs {
+				buf = binary.LittleEndian.AppendUint16(buf, run.start)
+				buf = binary.LittleEndian.AppendUint16(buf, run.last-run.start)
+			}
+		case *bitmapContainer:
+			for _, word := range c.words {
+				buf = binary.LittleEndian.AppendUint64(buf, word)
+			}
+		}
+	}
+
+	written, err := w.Write(buf)
+	return int64(written), err
+}
+
+// ReadFrom replaces the contents of the bitmap with a portable Roaring
+// serialization read from r.
+func (b *Bitmap) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	err := b.decode(cr)
+	return cr.n, wrapEOF(err)
+}
+
+// wrapEOF reports truncated input as ErrInvalidFormat.
+func wrapEOF(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the portable
+// Roaring format.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using the portable
+// Roaring format.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	_, err := b.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+func (b *Bitmap) decode(r *countingReader) error {
+	cookie, err := r.uint32()
+	if err != nil {
+		return err
+	}
+
+	var n int
+	var runFlags []byte
+	switch {
+	case cookie == serialCookieNoRunContainer:
+		size, err := r.uint32()
+		if err != nil {
+			return err
+		}
+		if size > 1<<16 {
+			return fmt.Errorf("%w: %d containers", ErrInvalidFormat, size)
+		}
+		n = int(size)
+	case cookie&0xFFFF == serialCookie:
+		n = int(cookie>>16) + 1
+		runFlags = make([]byte, (n+7)/8)
+		if err := r.read(runFlags); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown cookie %d", ErrInvalidFormat, cookie)
+	}
+
+	keys := make([]uint16, n)
+	cards := make([]int, n)
+	for i := 0; i < n; i++ {
+		if keys[i], err = r.uint16(); err != nil {
+			return err
+		}
+		card, err := r.uint16()
+		if err != nil {
+			return err
+		}
+		cards[i] = int(card) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return fmt.Errorf("%w: keys out of order", ErrInvalidFormat)
+		}
+	}
+
+	if runFlags == nil || n >= noOffsetThreshold {
+		// Containers are stored back to back, so the offsets are redundant
+		// for a sequential reader.
+		if err := r.read(make([]byte, 4*n)); err != nil {
+			return err
+		}
+	}
+
+	containers := make([]container, n)
+	for i := 0; i < n; i++ {
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+		switch {
+		case isRun:
+			numRuns, err := r.uint16()
+			if err != nil {
+				return err
+			}
+			if numRuns == 0 {
+				return fmt.Errorf("%w: empty run container", ErrInvalidFormat)
+			}
+			rc := &runContainer{runs: make([]interval16, numRuns)}
+			card := 0
+			for k := range rc.runs {
+				start, err := r.uint16()
+				if err != nil {
+					return err
+				}
+				length, err := r.uint16()
+				if err != nil {
+					return err
+				}
+				if int(start)+int(length) > 0xFFFF {
+					return fmt.Errorf("%w: run overflows container", ErrInvalidFormat)
+				}
+				// Adjacent runs must have been merged into one.
+				if k > 0 && int(start) <= int(rc.runs[k-1].last)+1 {
+					return fmt.Errorf("%w: runs out of order or adjacent", ErrInvalidFormat)
+				}
+				rc.runs[k] = interval16{start: start, last: start + length}
+				card += int(length) + 1
+			}
+			if card != cards[i] {
+				return fmt.Errorf("%w: run cardinality mismatch", ErrInvalidFormat)
+			}
+			containers[i] = rc
+		case cards[i] <= arrayMaxSize:
+			ac := &arrayContainer{content: make([]uint16, cards[i])}
+			for k := range ac.content {
+				if ac.content[k], err = r.uint16(); err != nil {
+					return err
+				}
+				if k > 0 && ac.content[k] <= ac.content[k-1] {
+					return fmt.Errorf("%w: array values out of order", ErrInvalidFormat)
+				}
+			}
+			containers[i] = ac
+		default:
+			bc := newBitmapContainer()
+			for k := range bc.words {
+				if bc.words[k], err = r.uint64(); err != nil {
+					return err
+				}
+				bc.card += bits.OnesCount64(bc.words[k])
+			}
+			if bc.card != cards[i] {
+				return fmt.Errorf("%w: bitmap cardinality mismatch", ErrInvalidFormat)
+			}
+			containers[i] = bc
+		}
+	}
+
+	b.keys = keys
+	b.containers = containers
+	return nil
+}
+
+// countingReader decodes little-endian integers and tracks bytes consumed.
+type countingReader struct {
+	r       io.Reader
+	n       int64
+	scratch [8]byte
+}
+
+func (c *countingReader) read(p []byte) error {
+	m, err := io.ReadFull(c.r, p)
+	c.n += int64(m)
+	return err
+}
+
+func (c *countingReader) uint16() (uint16, error) {
+	err := c.read(c.scratch[:2])
+	return binary.LittleEndian.Uint16(c.scratch[:2]), err
+}
+
+func (c *countingReader) uint32() (uint32, error) {
+	err := c.read(c.scratch[:4])
+	return binary.LittleEndian.Uint32(c.scratch[:4]), err
+}
+
+func (c *countingReader) uint64() (uint64, error) {
+	err := c.read(c.scratch[:8])
+	return binary.LittleEndian.Uint64(c.scratch[:8]), err
+}
@@ -0,0 +1,116 @@
+package roaring
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestWriteToPortableFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		bitmap   *Bitmap
+		expected []byte
+	}{
+		{
+			name:     "Empty",
+			bitmap:   New(),
+			expected: []byte{0x3A, 0x30, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:   "Arrays",
+			bitmap: Of(1, 2, 2<<16+5),
+			expected: []byte{
+				0x3A, 0x30, 0, 0, 2, 0, 0, 0, // cookie, container count
+				0, 0, 1, 0, 2, 0, 0, 0, // keys and cardinality-1
+				24, 0, 0, 0, 28, 0, 0, 0, // offsets
+				1, 0, 2, 0, 5, 0, // containers
+			},
+		},
+		{
+			name: "Run",
+			bitmap: func() *Bitmap {
+				b := New()
+				b.AddRange(0, 100)
+				return b
+			}(),
+			expected: []byte{
+				0x3B, 0x30, 0, 0, // cookie with container count-1
+				1,           // run flags
+				0, 0, 99, 0, // key and cardinality-1
+				1, 0, 0, 0, 99, 0, // one run: start 0, length-1 99
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := tt.bitmap.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !bytes.Equal(data, tt.expected) {
+			t.Errorf("%s: expected % x, got % x", tt.name, tt.expected, data)
+		}
+		if len(data) != tt.bitmap.SerializedSize() {
+			t.Errorf("%s: SerializedSize %d does not match %d written bytes", tt.name, tt.bitmap.SerializedSize(), len(data))
+		}
+	}
+}
+
+func TestSerializationRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for round := 0; round < 4; round++ {
+		b, _ := randomBitmap(rng)
+		if round%2 == 0 {
+			b.RunOptimize()
+		}
+		var buf bytes.Buffer
+		n, err := b.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if int(n) != b.SerializedSize() {
+			t.Errorf("Expected %d bytes written, got %d", b.SerializedSize(), n)
+		}
+
+		decoded := New()
+		read, err := decoded.ReadFrom(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if read != n {
+			t.Errorf("Expected %d bytes read, got %d", n, read)
+		}
+		if !decoded.Equal(b) {
+			t.Fatalf("Decoded bitmap does not match original")
+		}
+	}
+}
+
+func TestReadFromInvalid(t *testing.T) {
+	data, _ := Of(1, 2, 3).MarshalBinary()
+	inputs := [][]byte{
+		{},
+		{1, 2, 3, 4, 0, 0, 0, 0},
+		data[:len(data)-1],
+		// An array container holding 5 twice.
+		{0x3a, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 16, 0, 0, 0, 5, 0, 5, 0},
+		// An array container holding 5 then 3.
+		{0x3a, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 16, 0, 0, 0, 5, 0, 3, 0},
+		// A run container with no runs.
+		{0x3b, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		// A run container whose runs [10, 10] and [5, 5] are out of order.
+		{0x3b, 0x30, 0, 0, 1, 0, 0, 1, 0, 2, 0, 10, 0, 0, 0, 5, 0, 0, 0},
+		// Adjacent runs [1, 3] and [4, 5], which should have been one run.
+		{0x3b, 0x30, 0, 0, 1, 0, 0, 4, 0, 2, 0, 1, 0, 2, 0, 4, 0, 1, 0},
+		// A run [1, 3] under a header cardinality of 5.
+		{0x3b, 0x30, 0, 0, 1, 0, 0, 4, 0, 1, 0, 1, 0, 2, 0},
+	}
+	for _, input := range inputs {
+		err := New().UnmarshalBinary(input)
+		if !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat for % x, got %v", input, err)
+		}
+	}
+}
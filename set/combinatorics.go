@@ -0,0 +1,215 @@
+package set
+
+import (
+	"errors"
+	"math/bits"
+	"reflect"
+	"sort"
+)
+
+// MaxGenerated is the largest number of results a generator will agree to
+// produce. Requests beyond it return ErrTooLarge before generating anything.
+const MaxGenerated = 1 << 24
+
+// ErrTooLarge is returned by generators whose output would exceed
+// MaxGenerated results.
+var ErrTooLarge = errors.New("set: generator would produce too many results")
+
+// Seq is a lazy sequence: it calls yield for each value in turn and stops
+// early when yield returns false. It has the same shape as iter.Seq.
+type Seq[V any] func(yield func(V) bool)
+
+// sortedElements returns the elements of s, sorted when T is an integer,
+// float or string type so that generators produce a deterministic order.
+func sortedElements[T comparable](s *Set[T]) []T {
+	elems := s.ToSlice()
+	var zero T
+	typ := reflect.TypeOf(zero)
+	if typ == nil {
+		return elems
+	}
+	value := func(i int) reflect.Value { return reflect.ValueOf(elems[i]) }
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sort.Slice(elems, func(i, j int) bool { return value(i).Int() < value(j).Int() })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sort.Slice(elems, func(i, j int) bool { return value(i).Uint() < value(j).Uint() })
+	case reflect.Float32, reflect.Float64:
+		sort.Slice(elems, func(i, j int) bool { return value(i).Float() < value(j).Float() })
+	case reflect.String:
+		sort.Slice(elems, func(i, j int) bool { return value(i).String() < value(j).String() })
+	}
+	return elems
+}
+
+// PowerSet returns a sequence of every subset of s, without materialising
+// all 2^n subsets at once. Subset i holds the elements whose bit is set in
+// i, counting over the sorted elements for ordered types.
+func PowerSet[T comparable](s *Set[T]) (Seq[*Set[T]], error) {
+	elems := sortedElements(s)
+	if len(elems) >= bits.Len(MaxGenerated) {
+		return nil, ErrTooLarge
+	}
+	return func(yield func(*Set[T]) bool) {
+		for mask := uint64(0); mask < 1<<len(elems); mask++ {
+			subset := New[T]()
+			for i, elem := range elems {
+				if mask&(1<<i) != 0 {
+					subset.Add(elem)
+				}
+			}
+			if !yield(subset) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Product returns a sequence of every tuple taking one element from each
+// set in turn, varying the last position fastest. Like Python's
+// itertools.product, the product of no sets is a single empty tuple.
+func Product[T comparable](sets ...*Set[T]) (Seq[[]T], error) {
+	pools := make([][]T, len(sets))
+	total := uint64(1)
+	for i, s := range sets {
+		pools[i] = sortedElements(s)
+		hi, lo := bits.Mul64(total, uint64(len(pools[i])))
+		if hi != 0 || lo > MaxGenerated {
+			return nil, ErrTooLarge
+		}
+		total = lo
+	}
+	return func(yield func([]T) bool) {
+		if total == 0 {
+			return
+		}
+		indices := make([]int, len(pools))
+		for {
+			tuple := make([]T, len(pools))
+			for i, idx := range indices {
+				tuple[i] = pools[i][idx]
+			}
+			if !yield(tuple) {
+				return
+			}
+			// Advance the odometer from the last position.
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(pools[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}, nil
+}
+
+// binomial returns n choose k, or false if it exceeds MaxGenerated.
+func binomial(n, k int) (uint64, bool) {
+	if k > n-k {
+		k = n - k
+	}
+	result := uint64(1)
+	for i := 1; i <= k; i++ {
+		// result * (n-k+i) / i stays exact at every step.
+		hi, lo := bits.Mul64(result, uint64(n-k+i))
+		if hi != 0 {
+			return 0, false
+		}
+		result = lo / uint64(i)
+		if result > MaxGenerated {
+			return 0, false
+		}
+	}
+	return result, true
+}
+
+// Combinations returns a sequence of every k-element combination of s in
+// lexicographic order of positions.
+func Combinations[T comparable](s *Set[T], k int) (Seq[[]T], error) {
+	elems := sortedElements(s)
+	n := len(elems)
+	if k < 0 || k > n {
+		return func(func([]T) bool) {}, nil
+	}
+	if _, ok := binomial(n, k); !ok {
+		return nil, ErrTooLarge
+	}
+	return func(yield func([]T) bool) {
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			combo := make([]T, k)
+			for i, idx := range indices {
+				combo[i] = elems[idx]
+			}
+			if !yield(combo) {
+				return
+			}
+			// Find the rightmost index that can still move right.
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}, nil
+}
+
+// Permutations returns a sequence of every ordered arrangement of k
+// distinct elements of s, in lexicographic order of positions. A k of -1
+// means all elements.
+func Permutations[T comparable](s *Set[T], k int) (Seq[[]T], error) {
+	elems := sortedElements(s)
+	n := len(elems)
+	if k == -1 {
+		k = n
+	}
+	if k < 0 || k > n {
+		return func(func([]T) bool) {}, nil
+	}
+	total := uint64(1)
+	for i := 0; i < k; i++ {
+		total *= uint64(n - i)
+		if total > MaxGenerated {
+			return nil, ErrTooLarge
+		}
+	}
+	return func(yield func([]T) bool) {
+		used := make([]bool, n)
+		perm := make([]T, 0, k)
+		var walk func() bool
+		walk = func() bool {
+			if len(perm) == k {
+				return yield(append([]T(nil), perm...))
+			}
+			for i, elem := range elems {
+				if used[i] {
+					continue
+				}
+				used[i] = true
+				perm = append(perm, elem)
+				ok := walk()
+				perm = perm[:len(perm)-1]
+				used[i] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		walk()
+	}, nil
+}
@@ -0,0 +1,143 @@
+package set
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func collect[V any](t *testing.T, seq Seq[V], err error) []V {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result := []V{}
+	seq(func(v V) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+func setOf[T comparable](elems ...T) *Set[T] {
+	s := New[T]()
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+func TestPowerSet(t *testing.T) {
+	seq, err := PowerSet(setOf(3, 1, 2))
+	subsets := collect(t, seq, err)
+	if len(subsets) != 8 {
+		t.Fatalf("Expected 8 subsets, got %d", len(subsets))
+	}
+	expected := [][]int{{}, {1}, {2}, {1, 2}, {3}, {1, 3}, {2, 3}, {1, 2, 3}}
+	for i, subset := range subsets {
+		if !subset.Equal(setOf(expected[i]...)) {
+			t.Errorf("Subset %d: expected %v, got %v", i, expected[i], subset.ToSlice())
+		}
+	}
+
+	large := New[int]()
+	for i := 0; i < 40; i++ {
+		large.Add(i)
+	}
+	if _, err := PowerSet(large); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge for 40 elements, got %v", err)
+	}
+}
+
+func TestPowerSetStopsEarly(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 20; i++ {
+		s.Add(i)
+	}
+	seq, err := PowerSet(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	count := 0
+	seq(func(*Set[int]) bool {
+		count++
+		return count < 5
+	})
+	if count != 5 {
+		t.Errorf("Expected generation to stop after 5 subsets, got %d", count)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	seq, err := Product(setOf("b", "a"), setOf("x", "y"))
+	tuples := collect(t, seq, err)
+	expected := [][]string{{"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "y"}}
+	if !reflect.DeepEqual(tuples, expected) {
+		t.Errorf("Expected %v, got %v", expected, tuples)
+	}
+
+	intSeq, err := Product(setOf(1, 2), New[int]())
+	if got := collect(t, intSeq, err); len(got) != 0 {
+		t.Errorf("Expected no tuples with an empty factor, got %v", got)
+	}
+
+	intSeq, err = Product[int]()
+	if got := collect(t, intSeq, err); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Errorf("Expected one empty tuple from no factors, got %v", got)
+	}
+
+	big := New[int]()
+	for i := 0; i < 1000; i++ {
+		big.Add(i)
+	}
+	if _, err := Product(big, big, big); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	seq, err := Combinations(setOf(4, 2, 3, 1), 2)
+	combos := collect(t, seq, err)
+	expected := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if !reflect.DeepEqual(combos, expected) {
+		t.Errorf("Expected %v, got %v", expected, combos)
+	}
+
+	seq, err = Combinations(setOf(1, 2), 3)
+	if got := collect(t, seq, err); len(got) != 0 {
+		t.Errorf("Expected no combinations for k > n, got %v", got)
+	}
+
+	big := New[int]()
+	for i := 0; i < 100; i++ {
+		big.Add(i)
+	}
+	if _, err := Combinations(big, 50); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+	if _, err := Combinations(big, 2); err != nil {
+		t.Errorf("Expected 100 choose 2 to be allowed, got %v", err)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	seq, err := Permutations(setOf(3, 1, 2), -1)
+	perms := collect(t, seq, err)
+	expected := [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}
+	if !reflect.DeepEqual(perms, expected) {
+		t.Errorf("Expected %v, got %v", expected, perms)
+	}
+
+	strSeq, err := Permutations(setOf("a", "b", "c"), 2)
+	if got := collect(t, strSeq, err); len(got) != 6 {
+		t.Errorf("Expected 6 2-permutations, got %v", got)
+	}
+
+	big := New[int]()
+	for i := 0; i < 20; i++ {
+		big.Add(i)
+	}
+	if _, err := Permutations(big, -1); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
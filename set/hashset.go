@@ -0,0 +1,255 @@
+package set
+
+import (
+	"bytes"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ayush-raj8/advancedDataStructure/hasher"
+)
+
+// Hasher defines element identity for a HashSet. Elements that are Equal
+// must have the same Hash.
+type Hasher[T any] struct {
+	Hash  func(T) uint64
+	Equal func(a, b T) bool
+}
+
+// FoldedStringHasher treats strings as equal under Unicode case folding,
+// matching strings.EqualFold.
+func FoldedStringHasher() Hasher[string] {
+	return Hasher[string]{
+		Hash: func(s string) uint64 {
+			folded := make([]byte, 0, len(s))
+			for _, r := range s {
+				folded = utf8.AppendRune(folded, foldRune(r))
+			}
+			return hasher.Bytes(folded)
+		},
+		Equal: func(a, b string) bool {
+			return foldEqual(a, b)
+		},
+	}
+}
+
+// foldRune maps a rune to the smallest rune in its case-folding orbit, so
+// all case variants share one representative.
+func foldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+	return smallest
+}
+
+func foldEqual(a, b string) bool {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if foldRune(ra) != foldRune(rb) {
+			return false
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return a == b
+}
+
+// BytesHasher compares byte slices by content.
+func BytesHasher() Hasher[[]byte] {
+	return Hasher[[]byte]{
+		Hash:  hasher.Bytes,
+		Equal: bytes.Equal,
+	}
+}
+
+// KeyHasher identifies elements by a comparable key, for example an ID
+// field of a struct that also holds slices or maps.
+func KeyHasher[T any, K comparable](key func(T) K) Hasher[T] {
+	hashKey := hasher.For[K]()
+	return Hasher[T]{
+		Hash:  func(v T) uint64 { return hashKey(key(v)) },
+		Equal: func(a, b T) bool { return key(a) == key(b) },
+	}
+}
+
+// HashSet is a thread-safe set whose element identity is defined by a
+// Hasher rather than by ==, so it can hold slices, maps and other
+// non-comparable values. Elements with colliding hashes are chained.
+type HashSet[T any] struct {
+	buckets map[uint64][]T
+	size    int
+	hasher  Hasher[T]
+	mu      sync.RWMutex
+}
+
+// NewHashSet creates and returns a new HashSet using the given hasher.
+func NewHashSet[T any](h Hasher[T]) *HashSet[T] {
+	return &HashSet[T]{
+		buckets: make(map[uint64][]T),
+		hasher:  h,
+	}
+}
+
+// find returns the bucket of elem and its position in it, or -1.
+func (s *HashSet[T]) find(elem T) (uint64, int) {
+	h := s.hasher.Hash(elem)
+	for i, v := range s.buckets[h] {
+		if s.hasher.Equal(v, elem) {
+			return h, i
+		}
+	}
+	return h, -1
+}
+
+// Add inserts an element into the set. An element equal to one already
+// present is ignored.
+func (s *HashSet[T]) Add(elem T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if h, i := s.find(elem); i < 0 {
+		s.buckets[h] = append(s.buckets[h], elem)
+		s.size++
+	}
+}
+
+// Remove deletes an element from the set.
+func (s *HashSet[T]) Remove(elem T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, i := s.find(elem)
+	if i < 0 {
+		return
+	}
+	bucket := s.buckets[h]
+	bucket[i] = bucket[len(bucket)-1]
+	if len(bucket) == 1 {
+		delete(s.buckets, h)
+	} else {
+		s.buckets[h] = bucket[:len(bucket)-1]
+	}
+	s.size--
+}
+
+// Contains checks if an element is in the set.
+func (s *HashSet[T]) Contains(elem T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, i := s.find(elem)
+	return i >= 0
+}
+
+// Size returns the number of elements in the set.
+func (s *HashSet[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// ToSlice returns the elements of the set as a slice.
+func (s *HashSet[T]) ToSlice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	slice := make([]T, 0, s.size)
+	for _, bucket := range s.buckets {
+		slice = append(slice, bucket...)
+	}
+	return slice
+}
+
+// Clear removes every element from the set.
+func (s *HashSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets = make(map[uint64][]T)
+	s.size = 0
+}
+
+// ForEach applies the provided function to each element in the set.
+func (s *HashSet[T]) ForEach(f func(T)) {
+	for _, elem := range s.ToSlice() {
+		f(elem)
+	}
+}
+
+// Iterator returns a channel yielding every element of the set.
+func (s *HashSet[T]) Iterator() <-chan T {
+	ch := make(chan T)
+	go func() {
+		for _, elem := range s.ToSlice() {
+			ch <- elem
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Copy returns a new set with the same hasher and elements.
+func (s *HashSet[T]) Copy() *HashSet[T] {
+	result := NewHashSet(s.hasher)
+	s.ForEach(result.Add)
+	return result
+}
+
+// Union returns a new set that is the union of s and another set. The
+// result uses the hasher of s.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	result := s.Copy()
+	other.ForEach(result.Add)
+	return result
+}
+
+// Intersection returns a new set that is the intersection of s and another set.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) *HashSet[T] {
+	result := NewHashSet(s.hasher)
+	s.ForEach(func(elem T) {
+		if other.Contains(elem) {
+			result.Add(elem)
+		}
+	})
+	return result
+}
+
+// Difference returns a new set that is the difference of s and another set.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	result := NewHashSet(s.hasher)
+	s.ForEach(func(elem T) {
+		if !other.Contains(elem) {
+			result.Add(elem)
+		}
+	})
+	return result
+}
+
+// SymmetricDifference returns a new set with elements in either set but not in both.
+func (s *HashSet[T]) SymmetricDifference(other *HashSet[T]) *HashSet[T] {
+	result := s.Difference(other)
+	other.ForEach(func(elem T) {
+		if !s.Contains(elem) {
+			result.Add(elem)
+		}
+	})
+	return result
+}
+
+// IsSubset checks if the current set is a subset of another set.
+func (s *HashSet[T]) IsSubset(other *HashSet[T]) bool {
+	for _, elem := range s.ToSlice() {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset checks if the current set is a superset of another set.
+func (s *HashSet[T]) IsSuperset(other *HashSet[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal checks if the current set is equal to another set.
+func (s *HashSet[T]) Equal(other *HashSet[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
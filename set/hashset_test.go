@@ -0,0 +1,136 @@
+package set
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHashSetFoldedStrings(t *testing.T) {
+	s := NewHashSet(FoldedStringHasher())
+	s.Add("Hello")
+	s.Add("HELLO")
+	s.Add("straße")
+	if s.Size() != 2 {
+		t.Errorf("Expected case variants to collapse, got %v", s.ToSlice())
+	}
+	if !s.Contains("hello") {
+		t.Errorf("Expected case-insensitive Contains")
+	}
+	if !s.Contains("STRAßE") || s.Contains("strasse") {
+		t.Errorf("Expected simple folding semantics matching strings.EqualFold")
+	}
+	s.Remove("hElLo")
+	if s.Contains("Hello") || s.Size() != 1 {
+		t.Errorf("Expected Remove to match case-insensitively")
+	}
+}
+
+func TestHashSetBytes(t *testing.T) {
+	s := NewHashSet(BytesHasher())
+	s.Add([]byte("abc"))
+	s.Add([]byte("abc"))
+	s.Add([]byte("xyz"))
+	if s.Size() != 2 || !s.Contains([]byte("abc")) || s.Contains([]byte("ab")) {
+		t.Errorf("Expected byte slices to be compared by content, got %q", s.ToSlice())
+	}
+}
+
+type account struct {
+	ID    int
+	Roles []string
+}
+
+func TestHashSetKeyHasher(t *testing.T) {
+	s := NewHashSet(KeyHasher(func(a account) int { return a.ID }))
+	s.Add(account{ID: 1, Roles: []string{"admin"}})
+	s.Add(account{ID: 1, Roles: []string{"editor"}})
+	s.Add(account{ID: 2})
+	if s.Size() != 2 || !s.Contains(account{ID: 2, Roles: []string{"x"}}) {
+		t.Errorf("Expected accounts to be equal by ID, got %v", s.ToSlice())
+	}
+}
+
+func TestHashSetCollisions(t *testing.T) {
+	// Every element hashes alike, so all of them share one chain.
+	s := NewHashSet(Hasher[int]{
+		Hash:  func(int) uint64 { return 7 },
+		Equal: func(a, b int) bool { return a == b },
+	})
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	if s.Size() != 10 || len(s.buckets) != 1 {
+		t.Fatalf("Expected 10 chained elements in one bucket, got %d in %d", s.Size(), len(s.buckets))
+	}
+	s.Remove(3)
+	for i := 0; i < 10; i++ {
+		if s.Contains(i) != (i != 3) {
+			t.Errorf("Contains(%d) returned an unexpected result", i)
+		}
+	}
+	s.Clear()
+	if s.Size() != 0 || s.Contains(0) {
+		t.Errorf("Expected set to be empty after Clear")
+	}
+}
+
+func TestHashSetAlgebra(t *testing.T) {
+	a := NewHashSet(FoldedStringHasher())
+	b := NewHashSet(FoldedStringHasher())
+	for _, v := range []string{"a", "B", "c"} {
+		a.Add(v)
+	}
+	for _, v := range []string{"b", "C", "d"} {
+		b.Add(v)
+	}
+
+	tests := []struct {
+		name     string
+		result   *HashSet[string]
+		expected []string
+	}{
+		{"Union", a.Union(b), []string{"a", "b", "c", "d"}},
+		{"Intersection", a.Intersection(b), []string{"b", "c"}},
+		{"Difference", a.Difference(b), []string{"a"}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []string{"a", "d"}},
+	}
+	for _, tt := range tests {
+		expected := NewHashSet(FoldedStringHasher())
+		for _, v := range tt.expected {
+			expected.Add(v)
+		}
+		if !tt.result.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.result.ToSlice())
+		}
+	}
+	if !a.Intersection(b).IsSubset(a) || !a.Union(b).IsSuperset(b) {
+		t.Errorf("Subset relations do not hold")
+	}
+
+	count := 0
+	for range a.Iterator() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected Iterator to yield 3 elements, got %d", count)
+	}
+}
+
+func TestHashSetConcurrentAdd(t *testing.T) {
+	s := NewHashSet(BytesHasher())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Add([]byte{byte(i)})
+				s.Contains([]byte{byte(i)})
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Size() != 100 {
+		t.Errorf("Expected 100 elements, got %d", s.Size())
+	}
+}
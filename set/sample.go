@@ -0,0 +1,76 @@
+package set
+
+import "math/rand"
+
+// RandomElement returns an element chosen uniformly at random using rng,
+// and false if the set is empty. It runs in constant time.
+func (s *Set[T]) RandomElement(rng *rand.Rand) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.order) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.order[rng.Intn(len(s.order))], true
+}
+
+// PopRandom removes and returns an element chosen uniformly at random
+// using rng, and false if the set is empty.
+func (s *Set[T]) PopRandom(rng *rand.Rand) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.order) == 0 {
+		var zero T
+		return zero, false
+	}
+	elem := s.order[rng.Intn(len(s.order))]
+	s.remove(elem)
+	return elem, true
+}
+
+// Sample returns k distinct elements chosen uniformly at random without
+// replacement, in random order. It returns every element, shuffled, when k
+// is at least the size of the set. It runs in O(k) time using Floyd's
+// algorithm.
+func (s *Set[T]) Sample(k int, rng *rand.Rand) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := len(s.order)
+	if k > n {
+		k = n
+	}
+	if k <= 0 {
+		return []T{}
+	}
+	chosen := make(map[int]struct{}, k)
+	result := make([]T, 0, k)
+	for j := n - k; j < n; j++ {
+		i := rng.Intn(j + 1)
+		if _, taken := chosen[i]; taken {
+			i = j
+		}
+		chosen[i] = struct{}{}
+		result = append(result, s.order[i])
+	}
+	// Floyd's algorithm picks a uniform subset but not a uniform order.
+	rng.Shuffle(len(result), func(a, b int) { result[a], result[b] = result[b], result[a] })
+	return result
+}
+
+// ReservoirSample drains it and returns k of its values chosen uniformly at
+// random without replacement, or every value if it yields fewer than k. It
+// holds at most k values in memory, so it suits iterators whose length is
+// unknown, such as Set.Iterator or list.List.Iterator.
+func ReservoirSample[T any](it <-chan T, k int, rng *rand.Rand) []T {
+	reservoir := make([]T, 0, max(k, 0))
+	seen := 0
+	for v := range it {
+		seen++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+		} else if j := rng.Intn(seen); j < k {
+			reservoir[j] = v
+		}
+	}
+	return reservoir
+}
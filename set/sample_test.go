@@ -0,0 +1,128 @@
+package set
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRandomElementUniform(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[int]int)
+	const draws = 100000
+	for i := 0; i < draws; i++ {
+		elem, ok := s.RandomElement(rng)
+		if !ok {
+			t.Fatal("Expected an element from a non-empty set")
+		}
+		counts[elem]++
+	}
+	for elem, c := range counts {
+		if c < draws/10*9/10 || c > draws/10*11/10 {
+			t.Errorf("Element %d drawn %d times, expected about %d", elem, c, draws/10)
+		}
+	}
+	if _, ok := New[int]().RandomElement(rng); ok {
+		t.Errorf("Expected no element from an empty set")
+	}
+}
+
+func TestRandomElementAfterRemovals(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+	for i := 0; i < 100; i += 2 {
+		s.Remove(i)
+	}
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		if elem, _ := s.RandomElement(rng); elem%2 == 0 {
+			t.Fatalf("Drew removed element %d", elem)
+		}
+	}
+	for s.Size() > 0 {
+		elem, _ := s.PopRandom(rng)
+		if s.Contains(elem) {
+			t.Fatalf("PopRandom left %d in the set", elem)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 20; i++ {
+		s.Add(i)
+	}
+	sample := s.Sample(5, rand.New(rand.NewSource(3)))
+	if len(sample) != 5 {
+		t.Fatalf("Expected 5 elements, got %v", sample)
+	}
+	distinct := New[int]()
+	for _, v := range sample {
+		if !s.Contains(v) {
+			t.Errorf("Sample returned %d, which is not in the set", v)
+		}
+		distinct.Add(v)
+	}
+	if distinct.Size() != 5 {
+		t.Errorf("Expected distinct elements, got %v", sample)
+	}
+	if again := s.Sample(5, rand.New(rand.NewSource(3))); !reflect.DeepEqual(sample, again) {
+		t.Errorf("Expected the same seed to give the same sample, got %v and %v", sample, again)
+	}
+	if all := s.Sample(50, rand.New(rand.NewSource(3))); len(all) != 20 {
+		t.Errorf("Expected every element when k exceeds the size, got %d", len(all))
+	}
+	if none := s.Sample(-1, rand.New(rand.NewSource(3))); len(none) != 0 {
+		t.Errorf("Expected no elements for a negative k, got %v", none)
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 6; i++ {
+		s.Add(i)
+	}
+	rng := rand.New(rand.NewSource(4))
+	counts := make(map[int]int)
+	const draws = 30000
+	for i := 0; i < draws; i++ {
+		for _, v := range s.Sample(2, rng) {
+			counts[v]++
+		}
+	}
+	// Every element appears in a sample of 2 out of 6 with probability 1/3.
+	for elem, c := range counts {
+		if c < draws/3*9/10 || c > draws/3*11/10 {
+			t.Errorf("Element %d sampled %d times, expected about %d", elem, c, draws/3)
+		}
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	s := New[int]()
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	rng := rand.New(rand.NewSource(5))
+	counts := make(map[int]int)
+	const draws = 20000
+	for i := 0; i < draws; i++ {
+		for _, v := range ReservoirSample(s.Iterator(), 3, rng) {
+			counts[v]++
+		}
+	}
+	for elem, c := range counts {
+		if c < draws*3/10*9/10 || c > draws*3/10*11/10 {
+			t.Errorf("Element %d sampled %d times, expected about %d", elem, c, draws*3/10)
+		}
+	}
+	if got := ReservoirSample(s.Iterator(), 20, rng); len(got) != 10 {
+		t.Errorf("Expected every value from a short iterator, got %v", got)
+	}
+}
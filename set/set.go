@@ -1,17 +1,22 @@
 package set
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // Set is a thread-safe implementation of a set data structure.
 type Set[T comparable] struct {
-	elements map[T]struct{} // struct is 0 sized
+	elements map[T]int // position of each element in order
+	order    []T       // dense copy of the elements, for O(1) random access
 	mu       sync.RWMutex
+	id       atomic.Uint64 // see lockID
 }
 
 // New creates and returns a new instance of Set.
 func New[T comparable]() *Set[T] {
 	return &Set[T]{
-		elements: make(map[T]struct{}),
+		elements: make(map[T]int),
 	}
 }
 
@@ -19,13 +24,31 @@ func New[T comparable]() *Set[T] {
 func (s *Set[T]) Add(elem T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.elements[elem] = struct{}{}
+	if _, exists := s.elements[elem]; !exists {
+		s.elements[elem] = len(s.order)
+		s.order = append(s.order, elem)
+	}
 }
 
 // Remove deletes an element from the set.
 func (s *Set[T]) Remove(elem T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.remove(elem)
+}
+
+// remove deletes elem by moving the last element of order into its slot.
+func (s *Set[T]) remove(elem T) {
+	i, exists := s.elements[elem]
+	if !exists {
+		return
+	}
+	last := len(s.order) - 1
+	s.order[i] = s.order[last]
+	s.elements[s.order[i]] = i
+	var zero T
+	s.order[last] = zero
+	s.order = s.order[:last]
 	delete(s.elements, elem)
 }
 
@@ -48,11 +71,7 @@ func (s *Set[T]) Size() int {
 func (s *Set[T]) ToSlice() []T {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	slice := make([]T, 0, len(s.elements))
-	for key := range s.elements {
-		slice = append(slice, key)
-	}
-	return slice
+	return append(make([]T, 0, len(s.order)), s.order...)
 }
 
 // Union returns a new set that is the union of s and another set.
@@ -123,7 +142,8 @@ func (s *Set[T]) IsSuperset(other *Set[T]) bool {
 func (s *Set[T]) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.elements = make(map[T]struct{})
+	s.elements = make(map[T]int)
+	s.order = nil
 }
 
 func (s *Set[T]) Iterator() <-chan T {
@@ -131,7 +151,7 @@ func (s *Set[T]) Iterator() <-chan T {
 	go func() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
-		for elem := range s.elements {
+		for _, elem := range s.order {
 			ch <- elem
 		}
 		close(ch)
@@ -143,7 +163,7 @@ func (s *Set[T]) Iterator() <-chan T {
 func (s *Set[T]) ForEach(f func(T)) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	for elem := range s.elements {
+	for _, elem := range s.order {
 		f(elem)
 	}
 }
@@ -201,16 +221,18 @@ func (s *Set[T]) Equal(other *Set[T]) bool {
 	return true
 }
 
-// Pop removes and returns a random element from the set.
+// Pop removes and returns an arbitrary element from the set. The choice is
+// not uniformly random; use PopRandom for that.
 func (s *Set[T]) Pop() (T, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	for elem := range s.elements {
-		delete(s.elements, elem)
-		return elem, true
+	if len(s.order) == 0 {
+		var zero T
+		return zero, false
 	}
-	var zero T
-	return zero, false
+	elem := s.order[len(s.order)-1]
+	s.remove(elem)
+	return elem, true
 }
 
 // Reverse returns a new set with elements in reverse order.
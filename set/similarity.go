@@ -0,0 +1,93 @@
+package set
+
+import "sync/atomic"
+
+// lastLockID numbers sets in the order they first take part in a two-set
+// operation, giving every pair of sets a fixed lock order.
+var lastLockID atomic.Uint64
+
+// lockID returns the position of s in the package-wide lock order.
+func (s *Set[T]) lockID() uint64 {
+	if id := s.id.Load(); id != 0 {
+		return id
+	}
+	s.id.CompareAndSwap(0, lastLockID.Add(1))
+	return s.id.Load()
+}
+
+// intersect returns |a ∩ b|, |a| and |b| as of a single moment. Both read
+// locks are held at once, so they are taken in lockID order: two calls
+// with swapped arguments could otherwise each hold one lock and wait on
+// the other behind a pending writer.
+func intersect[T comparable](a, b *Set[T]) (inter, sizeA, sizeB int) {
+	if a == b {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+		n := len(a.elements)
+		return n, n, n
+	}
+	first, second := a, b
+	if first.lockID() > second.lockID() {
+		first, second = second, first
+	}
+	first.mu.RLock()
+	defer first.mu.RUnlock()
+	second.mu.RLock()
+	defer second.mu.RUnlock()
+
+	// Iterate over the smaller set and probe the larger one.
+	small, large := a.elements, b.elements
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for elem := range small {
+		if _, ok := large[elem]; ok {
+			inter++
+		}
+	}
+	return inter, len(a.elements), len(b.elements)
+}
+
+// IntersectionCount returns the number of elements in both s and other
+// without building the intersection.
+func (s *Set[T]) IntersectionCount(other *Set[T]) int {
+	inter, _, _ := intersect(s, other)
+	return inter
+}
+
+// Jaccard returns the Jaccard similarity |a ∩ b| / |a ∪ b| of two sets
+// without allocating. Two empty sets have a similarity of 1.
+func Jaccard[T comparable](a, b *Set[T]) float64 {
+	inter, sizeA, sizeB := intersect(a, b)
+	union := sizeA + sizeB - inter
+	if union == 0 {
+		return 1
+	}
+	return float64(inter) / float64(union)
+}
+
+// Overlap returns the overlap coefficient |a ∩ b| / min(|a|, |b|) of two
+// sets without allocating. It is 1 when both sets are empty and 0 when
+// only one is.
+func Overlap[T comparable](a, b *Set[T]) float64 {
+	inter, sizeA, sizeB := intersect(a, b)
+	if sizeA == 0 && sizeB == 0 {
+		return 1
+	}
+	smaller := min(sizeA, sizeB)
+	if smaller == 0 {
+		return 0
+	}
+	return float64(inter) / float64(smaller)
+}
+
+// Dice returns the Sørensen–Dice coefficient 2|a ∩ b| / (|a| + |b|) of two
+// sets without allocating. Two empty sets have a coefficient of 1.
+func Dice[T comparable](a, b *Set[T]) float64 {
+	inter, sizeA, sizeB := intersect(a, b)
+	total := sizeA + sizeB
+	if total == 0 {
+		return 1
+	}
+	return 2 * float64(inter) / float64(total)
+}
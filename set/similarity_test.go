@@ -0,0 +1,150 @@
+package set
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIntersectionCount(t *testing.T) {
+	s1 := New[int]()
+	s2 := New[int]()
+	for i := 0; i < 10; i++ {
+		s1.Add(i)
+	}
+	for i := 5; i < 8; i++ {
+		s2.Add(i)
+	}
+	if count := s1.IntersectionCount(s2); count != 3 {
+		t.Errorf("Expected intersection count 3, got %d", count)
+	}
+	if count := s2.IntersectionCount(s1); count != 3 {
+		t.Errorf("Expected intersection count 3, got %d", count)
+	}
+	if count := s1.IntersectionCount(s1); count != 10 {
+		t.Errorf("Expected self intersection count 10, got %d", count)
+	}
+}
+
+// TestIntersectionCountConcurrent calls IntersectionCount with swapped
+// arguments while writers queue on both sets, which deadlocks if the two
+// read locks are taken in argument order.
+func TestIntersectionCountConcurrent(t *testing.T) {
+	a := New[int]()
+	b := New[int]()
+	for i := 0; i < 100; i++ {
+		a.Add(i)
+		b.Add(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 20000; i++ {
+					switch g {
+					case 0:
+						a.IntersectionCount(b)
+					case 1:
+						b.IntersectionCount(a)
+					case 2:
+						a.Add(i % 100) // takes the write lock without resizing
+					case 3:
+						b.Add(i % 100)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("IntersectionCount deadlocked")
+	}
+}
+
+// TestSimilarityConcurrent checks that the coefficients stay within [0, 1]
+// while writers empty and refill both sets, which needs the intersection
+// and both sizes to be read at the same moment.
+func TestSimilarityConcurrent(t *testing.T) {
+	a := New[int]()
+	b := New[int]()
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for _, s := range []*Set[int]{a, b} {
+		wg.Add(1)
+		go func(s *Set[int]) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for i := 0; i < 20; i++ {
+					s.Add(i)
+				}
+				s.Clear()
+			}
+		}(s)
+	}
+	for i := 0; i < 2000; i++ {
+		for name, c := range map[string]float64{"Jaccard": Jaccard(a, b), "Overlap": Overlap(a, b), "Dice": Dice(a, b)} {
+			if c < 0 || c > 1 {
+				t.Errorf("%s = %v, outside [0, 1]", name, c)
+			}
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestSimilarity(t *testing.T) {
+	a := New[string]()
+	b := New[string]()
+	for _, v := range []string{"a", "b", "c", "d"} {
+		a.Add(v)
+	}
+	for _, v := range []string{"c", "d", "e"} {
+		b.Add(v)
+	}
+	empty := New[string]()
+
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"Jaccard", Jaccard(a, b), 2.0 / 5.0},
+		{"Overlap", Overlap(a, b), 2.0 / 3.0},
+		{"Dice", Dice(a, b), 4.0 / 7.0},
+		{"JaccardEmpty", Jaccard(empty, New[string]()), 1},
+		{"OverlapOneEmpty", Overlap(a, empty), 0},
+		{"DiceOneEmpty", Dice(a, empty), 0},
+		{"JaccardMatchesAllocating", Jaccard(a, b), float64(a.Intersection(b).Size()) / float64(a.Union(b).Size())},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestJaccardDoesNotAllocate(t *testing.T) {
+	a := New[int]()
+	b := New[int]()
+	for i := 0; i < 100; i++ {
+		a.Add(i)
+		b.Add(i + 50)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		Jaccard(a, b)
+	})
+	if allocs > 1 {
+		t.Errorf("Expected Jaccard not to allocate per element, got %v allocations", allocs)
+	}
+}
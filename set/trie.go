@@ -0,0 +1,350 @@
+package set
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StringSet is the read side shared by Set[string] and StringTrieSet, so
+// the two can be combined with set algebra.
+type StringSet interface {
+	Contains(elem string) bool
+	ForEach(f func(string))
+}
+
+// StringTrieSet is a thread-safe set of strings stored in a radix tree.
+// Shared prefixes are stored once, and prefix queries visit only the
+// matching subtree instead of every element. Elements are always visited in
+// lexicographic (byte-wise) order.
+type StringTrieSet struct {
+	root trieNode
+	mu   sync.RWMutex
+}
+
+// trieNode is a radix tree node. Its label is the edge leading to it, and
+// size counts the elements stored in its subtree. The int32 count keeps a
+// node within a 48-byte allocation.
+type trieNode struct {
+	label    string
+	children []*trieNode // sorted by the first byte of their labels
+	size     int32
+	terminal bool
+}
+
+// NewStringTrieSet creates and returns a new, empty StringTrieSet.
+func NewStringTrieSet() *StringTrieSet {
+	return &StringTrieSet{}
+}
+
+// TrieFromSet creates a StringTrieSet holding the elements of s.
+func TrieFromSet(s *Set[string]) *StringTrieSet {
+	t := NewStringTrieSet()
+	s.ForEach(t.Add)
+	return t
+}
+
+// ToSet returns a Set[string] holding the elements of t.
+func (t *StringTrieSet) ToSet() *Set[string] {
+	result := New[string]()
+	t.ForEach(result.Add)
+	return result
+}
+
+// child returns the position of the child whose label starts with b, and
+// whether it exists.
+func (n *trieNode) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	return i, i < len(n.children) && n.children[i].label[0] == b
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func (n *trieNode) insert(s string) bool {
+	if s == "" {
+		if n.terminal {
+			return false
+		}
+		n.terminal = true
+		n.size++
+		return true
+	}
+	i, found := n.child(s[0])
+	if !found {
+		// Copy the label so the tree does not pin the caller's whole string.
+		leaf := &trieNode{label: strings.Clone(s), terminal: true, size: 1}
+		n.children = append(n.children, nil)
+		copy(n.children[i+1:], n.children[i:])
+		n.children[i] = leaf
+		n.size++
+		return true
+	}
+	c := n.children[i]
+	common := commonPrefix(c.label, s)
+	if common < len(c.label) {
+		// Split the edge so the shared part becomes its own node.
+		split := &trieNode{label: c.label[:common], children: []*trieNode{c}, size: c.size}
+		c.label = c.label[common:]
+		n.children[i] = split
+		c = split
+	}
+	if !c.insert(s[common:]) {
+		return false
+	}
+	n.size++
+	return true
+}
+
+func (n *trieNode) remove(s string) bool {
+	if s == "" {
+		if !n.terminal {
+			return false
+		}
+		n.terminal = false
+		n.size--
+		return true
+	}
+	i, found := n.child(s[0])
+	if !found {
+		return false
+	}
+	c := n.children[i]
+	if !strings.HasPrefix(s, c.label) || !c.remove(s[len(c.label):]) {
+		return false
+	}
+	n.size--
+	switch {
+	case c.size == 0:
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	case !c.terminal && len(c.children) == 1:
+		// Merge a pass-through node into its only child.
+		only := c.children[0]
+		only.label = c.label + only.label
+		n.children[i] = only
+	}
+	return true
+}
+
+// find returns the node whose path is the shortest extension of prefix,
+// along with that path, or nil if no element starts with prefix.
+func (n *trieNode) find(prefix string) (*trieNode, string) {
+	path := ""
+	for prefix != "" {
+		i, found := n.child(prefix[0])
+		if !found {
+			return nil, ""
+		}
+		c := n.children[i]
+		switch {
+		case strings.HasPrefix(c.label, prefix):
+			return c, path + c.label
+		case strings.HasPrefix(prefix, c.label):
+			path += c.label
+			prefix = prefix[len(c.label):]
+			n = c
+		default:
+			return nil, ""
+		}
+	}
+	return n, path
+}
+
+// walk calls f with every element below n in lexicographic order, where
+// path spells the labels from the root to n. It stops when f returns false.
+func (n *trieNode) walk(path string, f func(string) bool) bool {
+	if n.terminal && !f(path) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(path+c.label, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds an element to the set.
+func (t *StringTrieSet) Add(elem string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root.insert(elem)
+}
+
+// Remove removes an element from the set.
+func (t *StringTrieSet) Remove(elem string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root.remove(elem)
+}
+
+// Contains checks if an element is in the set.
+func (t *StringTrieSet) Contains(elem string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	n, path := t.root.find(elem)
+	return n != nil && path == elem && n.terminal
+}
+
+// Size returns the number of elements in the set.
+func (t *StringTrieSet) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return int(t.root.size)
+}
+
+// HasPrefix reports whether any element starts with prefix.
+func (t *StringTrieSet) HasPrefix(prefix string) bool {
+	return t.CountPrefix(prefix) > 0
+}
+
+// CountPrefix returns the number of elements starting with prefix.
+func (t *StringTrieSet) CountPrefix(prefix string) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if n, _ := t.root.find(prefix); n != nil {
+		return int(n.size)
+	}
+	return 0
+}
+
+// WithPrefix returns a sequence of the elements starting with prefix, in
+// lexicographic order. The matching elements are copied when the sequence
+// is iterated, so the set may be modified while consuming it.
+func (t *StringTrieSet) WithPrefix(prefix string) Seq[string] {
+	return func(yield func(string) bool) {
+		var elems []string
+		t.mu.RLock()
+		if n, path := t.root.find(prefix); n != nil {
+			elems = make([]string, 0, int(n.size))
+			n.walk(path, func(elem string) bool {
+				elems = append(elems, elem)
+				return true
+			})
+		}
+		t.mu.RUnlock()
+		for _, elem := range elems {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// LongestPrefixOf returns the longest element that is a prefix of s, and
+// false if there is none.
+func (t *StringTrieSet) LongestPrefixOf(s string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	n := &t.root
+	longest, found := "", n.terminal
+	consumed := 0
+	for consumed < len(s) {
+		i, ok := n.child(s[consumed])
+		if !ok || !strings.HasPrefix(s[consumed:], n.children[i].label) {
+			break
+		}
+		n = n.children[i]
+		consumed += len(n.label)
+		if n.terminal {
+			longest, found = s[:consumed], true
+		}
+	}
+	return longest, found
+}
+
+// ToSlice returns the elements of the set in lexicographic order.
+func (t *StringTrieSet) ToSlice() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]string, 0, int(t.root.size))
+	t.root.walk("", func(elem string) bool {
+		result = append(result, elem)
+		return true
+	})
+	return result
+}
+
+// Clear removes all elements from the set.
+func (t *StringTrieSet) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = trieNode{}
+}
+
+// ForEach applies the provided function to each element in lexicographic order.
+func (t *StringTrieSet) ForEach(f func(string)) {
+	for _, elem := range t.ToSlice() {
+		f(elem)
+	}
+}
+
+// Iterator returns a channel yielding the elements in lexicographic order.
+func (t *StringTrieSet) Iterator() <-chan string {
+	ch := make(chan string)
+	go func() {
+		for _, elem := range t.ToSlice() {
+			ch <- elem
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Copy returns a new set with the same elements.
+func (t *StringTrieSet) Copy() *StringTrieSet {
+	result := NewStringTrieSet()
+	t.ForEach(result.Add)
+	return result
+}
+
+// Union returns a new set holding the elements of t and other.
+func (t *StringTrieSet) Union(other StringSet) *StringTrieSet {
+	result := t.Copy()
+	other.ForEach(result.Add)
+	return result
+}
+
+// Intersection returns a new set holding the elements of t that are also
+// in other.
+func (t *StringTrieSet) Intersection(other StringSet) *StringTrieSet {
+	result := NewStringTrieSet()
+	t.ForEach(func(elem string) {
+		if other.Contains(elem) {
+			result.Add(elem)
+		}
+	})
+	return result
+}
+
+// Difference returns a new set holding the elements of t that are not in
+// other.
+func (t *StringTrieSet) Difference(other StringSet) *StringTrieSet {
+	result := NewStringTrieSet()
+	t.ForEach(func(elem string) {
+		if !other.Contains(elem) {
+			result.Add(elem)
+		}
+	})
+	return result
+}
+
+// IsSubset checks if every element of t is in other.
+func (t *StringTrieSet) IsSubset(other StringSet) bool {
+	for _, elem := range t.ToSlice() {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal checks if t and other hold the same elements.
+func (t *StringTrieSet) Equal(other *StringTrieSet) bool {
+	return t.Size() == other.Size() && t.IsSubset(other)
+}
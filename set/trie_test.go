@@ -0,0 +1,214 @@
+package set
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestStringTrieSetBasics(t *testing.T) {
+	s := NewStringTrieSet()
+	for _, w := range []string{"team", "tea", "ten", "to", "", "inn", "in", "tea"} {
+		s.Add(w)
+	}
+	if s.Size() != 7 {
+		t.Errorf("Expected 7 elements, got %d", s.Size())
+	}
+	for _, w := range []string{"tea", "team", "", "in"} {
+		if !s.Contains(w) {
+			t.Errorf("Expected set to contain %q", w)
+		}
+	}
+	for _, w := range []string{"te", "teams", "i", "x"} {
+		if s.Contains(w) {
+			t.Errorf("Expected set not to contain %q", w)
+		}
+	}
+	expected := []string{"", "in", "inn", "tea", "team", "ten", "to"}
+	if got := s.ToSlice(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	s.Remove("tea")
+	s.Remove("te") // not an element
+	s.Remove("")
+	expected = []string{"in", "inn", "team", "ten", "to"}
+	if got := s.ToSlice(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v after removals, got %v", expected, got)
+	}
+}
+
+func TestStringTrieSetPrefixQueries(t *testing.T) {
+	s := NewStringTrieSet()
+	for _, w := range []string{"/api/users", "/api/users/1", "/api/orders", "/static/app.js", "/"} {
+		s.Add(w)
+	}
+	if !s.HasPrefix("/api/u") || s.HasPrefix("/apix") {
+		t.Errorf("HasPrefix returned an unexpected result")
+	}
+	if n := s.CountPrefix("/api"); n != 3 {
+		t.Errorf("Expected 3 elements under /api, got %d", n)
+	}
+	if n := s.CountPrefix(""); n != 5 {
+		t.Errorf("Expected the empty prefix to count every element, got %d", n)
+	}
+	expected := []string{"/api/orders", "/api/users", "/api/users/1"}
+	if got := collect(t, s.WithPrefix("/api/"), nil); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := collect(t, s.WithPrefix("/nope"), nil); len(got) != 0 {
+		t.Errorf("Expected no matches, got %v", got)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+		found    bool
+	}{
+		{"/api/users/1/orders", "/api/users/1", true},
+		{"/api/users/2", "/api/users", true},
+		{"/api/ord", "/", true},
+		{"static", "", false},
+	}
+	for _, tt := range tests {
+		got, found := s.LongestPrefixOf(tt.input)
+		if got != tt.expected || found != tt.found {
+			t.Errorf("LongestPrefixOf(%q) = %q, %v; want %q, %v", tt.input, got, found, tt.expected, tt.found)
+		}
+	}
+}
+
+// TestStringTrieSetAgainstSet checks random operations against Set[string].
+func TestStringTrieSetAgainstSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	trie := NewStringTrieSet()
+	model := New[string]()
+	word := func() string {
+		b := make([]byte, rng.Intn(5))
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		w := word()
+		if rng.Intn(3) == 0 {
+			trie.Remove(w)
+			model.Remove(w)
+		} else {
+			trie.Add(w)
+			model.Add(w)
+		}
+		prefix := word()
+		want := 0
+		model.ForEach(func(s string) {
+			if strings.HasPrefix(s, prefix) {
+				want++
+			}
+		})
+		if got := trie.CountPrefix(prefix); got != want {
+			t.Fatalf("step %d: CountPrefix(%q) = %d, want %d", i, prefix, got, want)
+		}
+	}
+	expected := model.ToSlice()
+	sort.Strings(expected)
+	if got := trie.ToSlice(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestStringTrieSetInterop(t *testing.T) {
+	plain := New[string]()
+	plain.Add("a")
+	plain.Add("b")
+	trie := TrieFromSet(plain)
+	other := New[string]()
+	other.Add("b")
+	other.Add("c")
+
+	if got := trie.Union(other).ToSlice(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Union: got %v", got)
+	}
+	if got := trie.Intersection(other).ToSlice(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Intersection: got %v", got)
+	}
+	if got := trie.Difference(other).ToSlice(); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Difference: got %v", got)
+	}
+	if !trie.IsSubset(plain) || !trie.ToSet().Equal(plain) {
+		t.Errorf("Expected the round trip through Set[string] to preserve elements")
+	}
+	if !trie.Equal(trie.Copy()) {
+		t.Errorf("Expected a copy to be equal")
+	}
+}
+
+// urlKeys returns n URL-like keys that share long prefixes, as routing
+// tables and crawl frontiers do.
+func urlKeys(n int) []string {
+	rng := rand.New(rand.NewSource(1))
+	hosts := []string{"https://example.com", "https://api.example.com", "https://cdn.example.org"}
+	sections := []string{"users", "orders", "products", "static/js", "static/css"}
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%s/v%d/%s/%d", hosts[rng.Intn(len(hosts))], 1+rng.Intn(2),
+			sections[rng.Intn(len(sections))], rng.Intn(n))
+	}
+	return keys
+}
+
+// heapBytes reports the live heap after building a structure with build.
+func heapBytes(build func() any) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	keep := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(keep)
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func BenchmarkStringTrieSetMemory(b *testing.B) {
+	keys := urlKeys(100000)
+	for i := 0; i < b.N; i++ {
+		used := heapBytes(func() any {
+			s := NewStringTrieSet()
+			for _, k := range keys {
+				s.Add(k)
+			}
+			return s
+		})
+		b.ReportMetric(float64(used)/float64(len(keys)), "B/key")
+	}
+}
+
+func BenchmarkSetMemory(b *testing.B) {
+	keys := urlKeys(100000)
+	for i := 0; i < b.N; i++ {
+		used := heapBytes(func() any {
+			s := New[string]()
+			for _, k := range keys {
+				// Copy each key so the set owns its strings, as the trie does.
+				s.Add(strings.Clone(k))
+			}
+			return s
+		})
+		b.ReportMetric(float64(used)/float64(len(keys)), "B/key")
+	}
+}
+
+func BenchmarkStringTrieSetWithPrefix(b *testing.B) {
+	s := NewStringTrieSet()
+	for _, k := range urlKeys(100000) {
+		s.Add(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.WithPrefix("https://api.example.com/v2/orders/9")(func(string) bool { return true })
+	}
+}
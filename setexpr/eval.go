@@ -0,0 +1,142 @@
+package setexpr
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+// Registry maps set names to the sets an expression is evaluated against.
+type Registry[T comparable] map[string]*set.Set[T]
+
+// Eval parses input and evaluates it against registry.
+func Eval[T comparable](input string, registry Registry[T]) (*set.Set[T], error) {
+	e, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(e, registry)
+}
+
+// Evaluate computes the set described by e. The sets in registry are never
+// modified, and the result is always a new set.
+//
+// Chains of ∩ are evaluated smallest operand first, stopping as soon as the
+// running result is empty, and each step scans only the smaller side. A
+// difference skips its right operand when the left one is empty. Operand
+// sizes are estimated from the registry before anything is computed. Every
+// name is checked up front, so an unknown name is reported even when
+// evaluation would have skipped it.
+func Evaluate[T comparable](e *Expr, registry Registry[T]) (*set.Set[T], error) {
+	for _, leaf := range leaves(e) {
+		if _, ok := registry[leaf.Name]; !ok {
+			return nil, &Error{Pos: leaf.Pos, Msg: fmt.Sprintf("no set named %q", leaf.Name), Err: ErrUnknownSet}
+		}
+	}
+	ev := evaluator[T]{registry: registry}
+	result, owned := ev.eval(e)
+	if !owned {
+		result = result.Copy()
+	}
+	return result, nil
+}
+
+func leaves(e *Expr) []*Expr {
+	if e.Left == nil {
+		return []*Expr{e}
+	}
+	return append(leaves(e.Left), leaves(e.Right)...)
+}
+
+// flatten returns the operands of a chain of the associative operator op
+// rooted at e, so that parenthesization does not constrain the order.
+func flatten(e *Expr, op Op) []*Expr {
+	if e.Left == nil || e.Op != op {
+		return []*Expr{e}
+	}
+	return append(flatten(e.Left, op), flatten(e.Right, op)...)
+}
+
+type evaluator[T comparable] struct {
+	registry Registry[T]
+}
+
+// estimate returns an upper bound on the size of e's result.
+func (ev evaluator[T]) estimate(e *Expr) int {
+	if e.Left == nil {
+		return ev.registry[e.Name].Size()
+	}
+	left, right := ev.estimate(e.Left), ev.estimate(e.Right)
+	switch e.Op {
+	case Intersection:
+		return min(left, right)
+	case Difference:
+		return left
+	default:
+		return left + right
+	}
+}
+
+// bySize returns operands sorted by estimated size, smallest first.
+func (ev evaluator[T]) bySize(operands []*Expr) []*Expr {
+	sizes := make(map[*Expr]int, len(operands))
+	for _, op := range operands {
+		sizes[op] = ev.estimate(op)
+	}
+	sorted := append([]*Expr(nil), operands...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sizes[sorted[i]] < sizes[sorted[j]] })
+	return sorted
+}
+
+// eval computes e, reporting whether the result is a fresh set the caller
+// may modify rather than a set from the registry.
+func (ev evaluator[T]) eval(e *Expr) (*set.Set[T], bool) {
+	if e.Left == nil {
+		return ev.registry[e.Name], false
+	}
+	switch e.Op {
+	case Intersection:
+		operands := ev.bySize(flatten(e, Intersection))
+		result, owned := ev.eval(operands[0])
+		for _, op := range operands[1:] {
+			if result.Size() == 0 {
+				break
+			}
+			next, _ := ev.eval(op)
+			// Intersection scans its receiver, so keep the smaller set there.
+			if next.Size() < result.Size() {
+				result, next = next, result
+			}
+			result, owned = result.Intersection(next), true
+		}
+		return result, owned
+	case Union:
+		operands := ev.bySize(flatten(e, Union))
+		// Start from the largest operand so the fewest elements are re-added.
+		result, owned := ev.eval(operands[len(operands)-1])
+		if !owned {
+			result = result.Copy()
+		}
+		for _, op := range operands[:len(operands)-1] {
+			next, _ := ev.eval(op)
+			next.ForEach(result.Add)
+		}
+		return result, true
+	case Difference:
+		left, owned := ev.eval(e.Left)
+		if left.Size() == 0 {
+			return left, owned
+		}
+		right, _ := ev.eval(e.Right)
+		return left.Difference(right), true
+	default:
+		operands := flatten(e, SymmetricDifference)
+		result, owned := ev.eval(operands[0])
+		for _, op := range operands[1:] {
+			next, _ := ev.eval(op)
+			result, owned = result.SymmetricDifference(next), true
+		}
+		return result, owned
+	}
+}
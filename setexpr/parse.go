@@ -0,0 +1,300 @@
+// Package setexpr parses and evaluates set algebra expressions such as
+// "(admins ∪ editors) − suspended" against a registry of named sets.
+//
+// Operators may be written as symbols or words, with words matched
+// case-insensitively:
+//
+//	union                 ∪  |  +  union
+//	intersection          ∩  &     intersect, intersection
+//	difference            −  -  \  minus, except
+//	symmetric difference  △  Δ  ^  xor, symdiff
+//
+// Intersection binds tighter than the other three operators, which share a
+// precedence level and associate to the left, so "a ∪ b ∩ c − d" means
+// "(a ∪ (b ∩ c)) − d". Parentheses group explicitly. Set names are runs of
+// letters, digits and the characters _ . : / ; any other name, including
+// one that collides with an operator word, can be written in double quotes.
+package setexpr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrSyntax is wrapped by errors for malformed expressions.
+	ErrSyntax = errors.New("setexpr: syntax error")
+	// ErrUnknownSet is wrapped by errors for names missing from the registry.
+	ErrUnknownSet = errors.New("setexpr: unknown set")
+)
+
+// Error reports a problem at a position in an expression. Pos is the
+// 1-based rune column of the offending token.
+type Error struct {
+	Pos int
+	Msg string
+	Err error // ErrSyntax or ErrUnknownSet
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v at position %d: %s", e.Err, e.Pos, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Op is a binary set operator.
+type Op int
+
+const (
+	Union Op = iota
+	Intersection
+	Difference
+	SymmetricDifference
+)
+
+func (op Op) String() string {
+	return [...]string{"∪", "∩", "−", "△"}[op]
+}
+
+// Expr is a parsed expression: either a set name or a binary operation.
+type Expr struct {
+	Op          Op
+	Name        string // set name; empty for an operation
+	Left, Right *Expr  // operands; nil for a name
+	Pos         int    // 1-based rune column of the name or operator
+}
+
+// String formats the expression with explicit parentheses around every
+// operation, quoting names that would not parse bare.
+func (e *Expr) String() string {
+	if e.Left == nil {
+		if isBareName(e.Name) {
+			return e.Name
+		}
+		return fmt.Sprintf("%q", e.Name)
+	}
+	return fmt.Sprintf("(%s %s %s)", e.Left, e.Op, e.Right)
+}
+
+// Names returns the set names referenced by the expression, in order of
+// appearance.
+func (e *Expr) Names() []string {
+	if e.Left == nil {
+		return []string{e.Name}
+	}
+	return append(e.Left.Names(), e.Right.Names()...)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	op   Op
+	pos  int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokName:
+		return fmt.Sprintf("name %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+var symbols = map[rune]Op{
+	'∪': Union, '|': Union, '+': Union,
+	'∩': Intersection, '&': Intersection,
+	'−': Difference, '-': Difference, '\\': Difference,
+	'△': SymmetricDifference, 'Δ': SymmetricDifference, '^': SymmetricDifference,
+}
+
+var words = map[string]Op{
+	"union":     Union,
+	"intersect": Intersection, "intersection": Intersection,
+	"minus": Difference, "except": Difference,
+	"xor": SymmetricDifference, "symdiff": SymmetricDifference,
+}
+
+func isNameRune(r rune) bool {
+	if _, isSymbol := symbols[r]; isSymbol {
+		// Δ is a letter, but here it is an operator.
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.:/", r)
+}
+
+func isBareName(s string) bool {
+	if s == "" {
+		return false
+	}
+	if _, isWord := words[strings.ToLower(s)]; isWord {
+		return false
+	}
+	for _, r := range s {
+		if !isNameRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// lex splits an expression into tokens, ending with tokEOF.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], i+1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: pos})
+			i++
+		case r == '"':
+			var name strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				name.WriteRune(runes[i])
+				i++
+			}
+			if i == len(runes) {
+				return nil, &Error{Pos: pos, Msg: "unterminated quoted name", Err: ErrSyntax}
+			}
+			tokens = append(tokens, token{kind: tokName, text: name.String(), pos: pos})
+			i++
+		case isNameRune(r):
+			start := i
+			for i < len(runes) && isNameRune(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			if op, isWord := words[strings.ToLower(text)]; isWord {
+				tokens = append(tokens, token{kind: tokOp, text: text, op: op, pos: pos})
+			} else {
+				tokens = append(tokens, token{kind: tokName, text: text, pos: pos})
+			}
+		default:
+			op, ok := symbols[r]
+			if !ok {
+				return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r), Err: ErrSyntax}
+			}
+			tokens = append(tokens, token{kind: tokOp, text: string(r), op: op, pos: pos})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokEOF {
+		p.next++
+	}
+	return t
+}
+
+// Parse parses an expression. Errors are *Error values wrapping ErrSyntax.
+func Parse(input string) (*Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &Error{Pos: t.pos, Msg: "expected operator, found " + t.describe(), Err: ErrSyntax}
+	}
+	return e, nil
+}
+
+// parseAdditive parses a left-associative chain of ∪, − and △.
+func (p *parser) parseAdditive() (*Expr, error) {
+	left, err := p.parseIntersection()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && t.op != Intersection; t = p.peek() {
+		p.advance()
+		right, err := p.parseIntersection()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expr{Op: t.op, Left: left, Right: right, Pos: t.pos}
+	}
+	return left, nil
+}
+
+// parseIntersection parses a left-associative chain of ∩.
+func (p *parser) parseIntersection() (*Expr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && t.op == Intersection; t = p.peek() {
+		p.advance()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expr{Op: Intersection, Left: left, Right: right, Pos: t.pos}
+	}
+	return left, nil
+}
+
+// parseOperand parses a name or a parenthesized expression.
+func (p *parser) parseOperand() (*Expr, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokName:
+		return &Expr{Name: t.text, Pos: t.pos}, nil
+	case tokLParen:
+		e, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokRParen {
+			return nil, &Error{
+				Pos: closing.pos,
+				Msg: fmt.Sprintf("expected \")\" to close \"(\" at position %d, found %s", t.pos, closing.describe()),
+				Err: ErrSyntax,
+			}
+		}
+		return e, nil
+	default:
+		return nil, &Error{Pos: t.pos, Msg: "expected set name or \"(\", found " + t.describe(), Err: ErrSyntax}
+	}
+}
@@ -0,0 +1,176 @@
+package setexpr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ayush-raj8/advancedDataStructure/set"
+)
+
+func setOf(elems ...string) *set.Set[string] {
+	s := set.New[string]()
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+func sorted(s *set.Set[string]) []string {
+	result := s.ToSlice()
+	sort.Strings(result)
+	return result
+}
+
+func testRegistry() Registry[string] {
+	return Registry[string]{
+		"admins":    setOf("ann", "bob"),
+		"editors":   setOf("bob", "cat", "dan"),
+		"suspended": setOf("dan"),
+		"empty":     setOf(),
+		"on-call":   setOf("ann", "eve"),
+		"union":     setOf("zed"),
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a ∪ b ∩ c − d", "((a ∪ (b ∩ c)) − d)"},
+		{"a - b - c", "((a − b) − c)"},
+		{"a union b intersect c", "(a ∪ (b ∩ c))"},
+		{"(a | b) & c ^ d", "(((a ∪ b) ∩ c) △ d)"},
+		{"aΔb", "(a △ b)"},
+		{`"on-call" EXCEPT "union"`, `("on-call" − "union")`},
+		{"team.a/x:1", "team.a/x:1"},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got := e.String(); got != tt.expected {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.expected)
+		}
+		if _, err := Parse(e.String()); err != nil {
+			t.Errorf("String of %q does not parse: %v", tt.input, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"", 1},
+		{"a ∪", 4},
+		{"(a ∪ b", 7},
+		{"a b", 3},
+		{"a ∪ )", 5},
+		{"a # b", 3},
+		{`a ∪ "b`, 5},
+		{"∩ a", 1},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var exprErr *Error
+		if !errors.As(err, &exprErr) || !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q): expected a syntax *Error, got %v", tt.input, err)
+			continue
+		}
+		if exprErr.Pos != tt.pos {
+			t.Errorf("Parse(%q): error at position %d, want %d (%v)", tt.input, exprErr.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	reg := testRegistry()
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"(admins ∪ editors) − suspended", []string{"ann", "bob", "cat"}},
+		{"(admins union editors) minus suspended", []string{"ann", "bob", "cat"}},
+		{"admins ∩ editors", []string{"bob"}},
+		{"admins △ editors", []string{"ann", "cat", "dan"}},
+		{`admins ∩ "on-call"`, []string{"ann"}},
+		{`editors ∩ empty ∩ (admins ∪ "on-call")`, []string{}},
+		{"empty − admins", []string{}},
+		{`"union" + empty`, []string{"zed"}},
+		{"admins ^ admins ^ admins", []string{"ann", "bob"}},
+	}
+	for _, tt := range tests {
+		result, err := Eval(tt.input, reg)
+		if err != nil {
+			t.Errorf("Eval(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got := sorted(result); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Eval(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestEvalDoesNotModifyRegistry(t *testing.T) {
+	reg := testRegistry()
+	result, err := Eval("admins", reg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result.Add("mallory")
+	if reg["admins"].Contains("mallory") {
+		t.Errorf("Expected the result to be a copy of the registry set")
+	}
+	if _, err := Eval("admins ∪ editors ∩ admins", reg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := sorted(reg["admins"]); !reflect.DeepEqual(got, []string{"ann", "bob"}) {
+		t.Errorf("Registry set was modified: %v", got)
+	}
+}
+
+func TestEvalUnknownSet(t *testing.T) {
+	_, err := Eval("empty ∩ (admins ∪ ghosts)", testRegistry())
+	var exprErr *Error
+	if !errors.As(err, &exprErr) || !errors.Is(err, ErrUnknownSet) {
+		t.Fatalf("Expected an unknown-set *Error, got %v", err)
+	}
+	if exprErr.Pos != 19 {
+		t.Errorf("Expected the error at position 19, got %d", exprErr.Pos)
+	}
+}
+
+func TestSmallestOperandsFirst(t *testing.T) {
+	reg := testRegistry()
+	e, err := Parse("editors ∩ (admins ∪ editors) ∩ suspended ∩ admins")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ev := evaluator[string]{registry: reg}
+	var order []string
+	for _, op := range ev.bySize(flatten(e, Intersection)) {
+		order = append(order, op.String())
+	}
+	expected := []string{"suspended", "admins", "editors", "(admins ∪ editors)"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected evaluation order %v, got %v", expected, order)
+	}
+}
+
+func ExampleEval() {
+	reg := Registry[string]{
+		"admins":    setOf("ann"),
+		"editors":   setOf("bob", "cat"),
+		"suspended": setOf("cat"),
+	}
+	result, _ := Eval("(admins ∪ editors) − suspended", reg)
+	fmt.Println(sorted(result))
+	// Output: [ann bob]
+}
@@ -0,0 +1,384 @@
+package skiplist
+
+import (
+	"cmp"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentSkipList is a thread-safe ordered map built as a lazy skip list
+// (Herlihy, Lev, Luchangco and Shavit, "A Simple Optimistic Skiplist
+// Algorithm", 2007). Insert and Delete lock only the nodes whose links they
+// change, so writers to different parts of the list run in parallel, and
+// lookups take no locks at all.
+//
+// Insert, Delete, Get and Contains are linearizable: concurrent calls
+// behave as if they ran one at a time in some order consistent with when
+// they were made. The ordered queries, iteration and Len are weakly
+// consistent instead: they see every update that finished before they
+// started and may or may not see updates made while they run. Rank and
+// ByRank walk the bottom level, so they take O(n) time rather than the
+// O(log n) of SkipList.
+type ConcurrentSkipList[K, V any] struct {
+	head    *concurrentNode[K, V]
+	length  atomic.Int64
+	compare func(a, b K) int
+	rngMu   sync.Mutex
+	rng     *rand.Rand
+}
+
+type concurrentNode[K, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+	next  []atomic.Pointer[concurrentNode[K, V]]
+	// mu guards changes to next and marked. Locks are taken in decreasing
+	// key order, which is the order a search meets the nodes bottom-up.
+	mu sync.Mutex
+	// marked is set when the node is logically deleted, before it is
+	// unlinked; fullyLinked is set once it is linked at every level, which
+	// is when an insert takes effect.
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+}
+
+// live reports whether x is in the list: linked at every level and not
+// deleted.
+func (x *concurrentNode[K, V]) live() bool {
+	return x.fullyLinked.Load() && !x.marked.Load()
+}
+
+func (x *concurrentNode[K, V]) entry() Entry[K, V] {
+	return Entry[K, V]{x.key, *x.value.Load()}
+}
+
+// NewConcurrent creates and returns a new, empty ConcurrentSkipList for an
+// ordered key type, drawing levels from a random source seeded with seed.
+func NewConcurrent[K cmp.Ordered, V any](seed int64) *ConcurrentSkipList[K, V] {
+	return NewConcurrentFunc[K, V](cmp.Compare[K], seed)
+}
+
+// NewConcurrentFunc creates an empty ConcurrentSkipList ordered by compare,
+// as in NewFunc.
+func NewConcurrentFunc[K, V any](compare func(a, b K) int, seed int64) *ConcurrentSkipList[K, V] {
+	head := &concurrentNode[K, V]{next: make([]atomic.Pointer[concurrentNode[K, V]], maxLevel)}
+	head.fullyLinked.Store(true)
+	return &ConcurrentSkipList[K, V]{
+		head:    head,
+		compare: compare,
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *ConcurrentSkipList[K, V]) randomLevel() int {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	level := 1
+	for level < maxLevel && s.rng.Float64() < p {
+		level++
+	}
+	return level
+}
+
+// find fills preds and succs with, for every level, the last node whose
+// key is less than key and the node after it. It returns the highest level
+// at which a node with key was found, or -1.
+func (s *ConcurrentSkipList[K, V]) find(key K, preds, succs *[maxLevel]*concurrentNode[K, V]) int {
+	found := -1
+	pred := s.head
+	for i := maxLevel - 1; i >= 0; i-- {
+		curr := pred.next[i].Load()
+		for curr != nil && s.compare(curr.key, key) < 0 {
+			pred, curr = curr, curr.next[i].Load()
+		}
+		if found == -1 && curr != nil && s.compare(curr.key, key) == 0 {
+			found = i
+		}
+		preds[i], succs[i] = pred, curr
+	}
+	return found
+}
+
+// lockPreds locks preds[0] to preds[levels-1], skipping repeats, and
+// reports whether none of them is deleted and each still links to
+// succs[i]. The caller must call unlockPreds whatever the result.
+func lockPreds[K, V any](preds, succs *[maxLevel]*concurrentNode[K, V], levels int) bool {
+	valid := true
+	for i := 0; i < levels; i++ {
+		pred := preds[i]
+		if i == 0 || pred != preds[i-1] {
+			pred.mu.Lock()
+		}
+		valid = valid && !pred.marked.Load() && pred.next[i].Load() == succs[i]
+	}
+	return valid
+}
+
+func unlockPreds[K, V any](preds *[maxLevel]*concurrentNode[K, V], levels int) {
+	for i := 0; i < levels; i++ {
+		if i == 0 || preds[i] != preds[i-1] {
+			preds[i].mu.Unlock()
+		}
+	}
+}
+
+// Insert sets the value for key, reporting whether key is new.
+func (s *ConcurrentSkipList[K, V]) Insert(key K, value V) bool {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	level := 0
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 {
+			x := succs[found]
+			if x.marked.Load() {
+				// A delete is unlinking x; search again once it is gone.
+				runtime.Gosched()
+				continue
+			}
+			for !x.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			x.mu.Lock()
+			if x.marked.Load() {
+				x.mu.Unlock()
+				continue
+			}
+			x.value.Store(&value)
+			x.mu.Unlock()
+			return false
+		}
+		if level == 0 {
+			level = s.randomLevel()
+		}
+		valid := lockPreds(&preds, &succs, level)
+		for i := 0; valid && i < level; i++ {
+			valid = succs[i] == nil || !succs[i].marked.Load()
+		}
+		if !valid {
+			unlockPreds(&preds, level)
+			continue
+		}
+		x := &concurrentNode[K, V]{key: key, next: make([]atomic.Pointer[concurrentNode[K, V]], level)}
+		x.value.Store(&value)
+		for i := 0; i < level; i++ {
+			x.next[i].Store(succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].next[i].Store(x)
+		}
+		x.fullyLinked.Store(true)
+		s.length.Add(1)
+		unlockPreds(&preds, level)
+		return true
+	}
+}
+
+// Delete removes key, reporting whether it was present.
+func (s *ConcurrentSkipList[K, V]) Delete(key K) bool {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	var victim *concurrentNode[K, V]
+	for {
+		found := s.find(key, &preds, &succs)
+		if victim == nil {
+			// A node still being inserted, or not yet linked at its top
+			// level when the search passed that level, had not taken
+			// effect, and one that is marked has already been deleted.
+			if found == -1 {
+				return false
+			}
+			x := succs[found]
+			if !x.fullyLinked.Load() || x.marked.Load() || found != len(x.next)-1 {
+				return false
+			}
+			x.mu.Lock()
+			if x.marked.Load() {
+				x.mu.Unlock()
+				return false
+			}
+			x.marked.Store(true)
+			victim = x
+		}
+		// victim is logically deleted and stays locked; unlink it once the
+		// nodes before it are stable.
+		level := len(victim.next)
+		for i := 0; i < level; i++ {
+			succs[i] = victim
+		}
+		if !lockPreds(&preds, &succs, level) {
+			unlockPreds(&preds, level)
+			continue
+		}
+		for i := level - 1; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+		s.length.Add(-1)
+		victim.mu.Unlock()
+		unlockPreds(&preds, level)
+		return true
+	}
+}
+
+// Get returns the value for key and whether key is present.
+func (s *ConcurrentSkipList[K, V]) Get(key K) (V, bool) {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	if found := s.find(key, &preds, &succs); found != -1 && succs[found].live() {
+		return *succs[found].value.Load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present.
+func (s *ConcurrentSkipList[K, V]) Contains(key K) bool {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	found := s.find(key, &preds, &succs)
+	return found != -1 && succs[found].live()
+}
+
+// Floor returns the entry with the greatest key less than or equal to key,
+// and false if there is none.
+func (s *ConcurrentSkipList[K, V]) Floor(key K) (Entry[K, V], bool) {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	for {
+		s.find(key, &preds, &succs)
+		if x := succs[0]; x != nil && s.compare(x.key, key) == 0 && x.live() {
+			return x.entry(), true
+		}
+		x := preds[0]
+		if x == s.head {
+			return Entry[K, V]{}, false
+		}
+		if x.live() {
+			return x.entry(), true
+		}
+		// The node before key is being inserted or deleted, and there is
+		// no link back to the one before it; search again.
+		runtime.Gosched()
+	}
+}
+
+// Ceiling returns the entry with the least key greater than or equal to
+// key, and false if there is none.
+func (s *ConcurrentSkipList[K, V]) Ceiling(key K) (Entry[K, V], bool) {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	s.find(key, &preds, &succs)
+	if x := s.firstLive(succs[0]); x != nil {
+		return x.entry(), true
+	}
+	return Entry[K, V]{}, false
+}
+
+// firstLive returns the first live node at or after x on the bottom level,
+// or nil.
+func (s *ConcurrentSkipList[K, V]) firstLive(x *concurrentNode[K, V]) *concurrentNode[K, V] {
+	for x != nil && !x.live() {
+		x = x.next[0].Load()
+	}
+	return x
+}
+
+// Min returns the entry with the least key, and false if the list is empty.
+func (s *ConcurrentSkipList[K, V]) Min() (Entry[K, V], bool) {
+	if x := s.firstLive(s.head.next[0].Load()); x != nil {
+		return x.entry(), true
+	}
+	return Entry[K, V]{}, false
+}
+
+// Max returns the entry with the greatest key, and false if the list is
+// empty.
+func (s *ConcurrentSkipList[K, V]) Max() (Entry[K, V], bool) {
+	for {
+		x := s.head
+		for i := maxLevel - 1; i >= 0; i-- {
+			for next := x.next[i].Load(); next != nil; next = x.next[i].Load() {
+				x = next
+			}
+		}
+		if x == s.head {
+			return Entry[K, V]{}, false
+		}
+		if x.live() {
+			return x.entry(), true
+		}
+		runtime.Gosched()
+	}
+}
+
+// Rank returns the number of keys less than key, which is key's 0-based
+// position if it is present, and whether it is present.
+func (s *ConcurrentSkipList[K, V]) Rank(key K) (int, bool) {
+	rank := 0
+	for x := s.firstLive(s.head.next[0].Load()); x != nil; x = s.firstLive(x.next[0].Load()) {
+		if c := s.compare(x.key, key); c >= 0 {
+			return rank, c == 0
+		}
+		rank++
+	}
+	return rank, false
+}
+
+// ByRank returns the entry at 0-based position r in key order, and false
+// if r is out of range.
+func (s *ConcurrentSkipList[K, V]) ByRank(r int) (Entry[K, V], bool) {
+	if r < 0 {
+		return Entry[K, V]{}, false
+	}
+	for x := s.firstLive(s.head.next[0].Load()); x != nil; x = s.firstLive(x.next[0].Load()) {
+		if r == 0 {
+			return x.entry(), true
+		}
+		r--
+	}
+	return Entry[K, V]{}, false
+}
+
+// Range calls f for each entry with a key in the half-open interval
+// [lo, hi), in key order, until f returns false. No lock is held while f
+// runs, so f may modify the list.
+func (s *ConcurrentSkipList[K, V]) Range(lo, hi K, f func(key K, value V) bool) {
+	var preds, succs [maxLevel]*concurrentNode[K, V]
+	s.find(lo, &preds, &succs)
+	for x := s.firstLive(succs[0]); x != nil && s.compare(x.key, hi) < 0; x = s.firstLive(x.next[0].Load()) {
+		if e := x.entry(); !f(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// ForEach calls f for every entry in key order. No lock is held while f
+// runs, so f may modify the list.
+func (s *ConcurrentSkipList[K, V]) ForEach(f func(key K, value V)) {
+	for x := s.firstLive(s.head.next[0].Load()); x != nil; x = s.firstLive(x.next[0].Load()) {
+		e := x.entry()
+		f(e.Key, e.Value)
+	}
+}
+
+// Entries returns every entry in key order.
+func (s *ConcurrentSkipList[K, V]) Entries() []Entry[K, V] {
+	result := make([]Entry[K, V], 0, s.Len())
+	s.ForEach(func(k K, v V) {
+		result = append(result, Entry[K, V]{k, v})
+	})
+	return result
+}
+
+// Iterator returns a channel that yields the entries in key order, as
+// Entries returned them when Iterator was called. Writers are not blocked
+// while the channel is read.
+func (s *ConcurrentSkipList[K, V]) Iterator() <-chan Entry[K, V] {
+	return sendAll(s.Entries())
+}
+
+func (s *ConcurrentSkipList[K, V]) Len() int {
+	return int(s.length.Load())
+}
+
+// Clear deletes every entry present when it is called. Entries inserted
+// while it runs may remain.
+func (s *ConcurrentSkipList[K, V]) Clear() {
+	for x := s.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+		s.Delete(x.key)
+	}
+}
@@ -0,0 +1,397 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type opKind int
+
+const (
+	opInsert opKind = iota
+	opDelete
+	opGet
+)
+
+// operation is one call in a recorded history. start and end come from a
+// shared clock, so operation a precedes b in real time if a.end < b.start.
+type operation struct {
+	kind       opKind
+	value      int  // value inserted, or value returned by Get
+	ok         bool // result of Insert, Delete or Get
+	start, end int64
+}
+
+func (o operation) String() string {
+	return fmt.Sprintf("%v(%d)=%v [%d,%d]", [...]string{"insert", "delete", "get"}[o.kind], o.value, o.ok, o.start, o.end)
+}
+
+// register is the state of one key: whether it is present, and its value.
+type register struct {
+	present bool
+	value   int
+}
+
+// apply runs o against r, reporting the new state and whether o's recorded
+// result is what a sequential skip list would have returned.
+func (o operation) apply(r register) (register, bool) {
+	switch o.kind {
+	case opInsert:
+		return register{true, o.value}, o.ok == !r.present
+	case opDelete:
+		return register{}, o.ok == r.present
+	default:
+		return r, o.ok == r.present && (!o.ok || o.value == r.value)
+	}
+}
+
+// linearizable reports whether the operations on one key can be ordered so
+// that each takes effect between its start and end and every result
+// matches a sequential execution. Operations on different keys commute, so
+// checking each key on its own checks the whole history. The search tries
+// every operation that no pending operation must precede, memoizing the
+// states already explored for each set of completed operations.
+func linearizable(ops []operation) bool {
+	type memoKey struct {
+		done  uint64
+		state register
+	}
+	seen := map[memoKey]bool{}
+	var search func(done uint64, state register) bool
+	search = func(done uint64, state register) bool {
+		if done == 1<<len(ops)-1 {
+			return true
+		}
+		if seen[memoKey{done, state}] {
+			return false
+		}
+		seen[memoKey{done, state}] = true
+		// Any pending operation that ended before another pending one
+		// started must be linearized first.
+		deadline := int64(1<<63 - 1)
+		for i, o := range ops {
+			if done&(1<<i) == 0 && o.end < deadline {
+				deadline = o.end
+			}
+		}
+		for i, o := range ops {
+			if done&(1<<i) != 0 || o.start > deadline {
+				continue
+			}
+			if next, ok := o.apply(state); ok && search(done|1<<i, next) {
+				return true
+			}
+		}
+		return false
+	}
+	return search(0, register{})
+}
+
+func TestLinearizableChecker(t *testing.T) {
+	// Two inserts of a new key cannot both succeed, even if they overlap.
+	bad := []operation{
+		{kind: opInsert, value: 1, ok: true, start: 0, end: 3},
+		{kind: opInsert, value: 2, ok: true, start: 1, end: 4},
+	}
+	if linearizable(bad) {
+		t.Errorf("Expected %v not to be linearizable", bad)
+	}
+	// A read that finishes before an insert starts cannot see it.
+	stale := []operation{
+		{kind: opGet, value: 5, ok: true, start: 0, end: 1},
+		{kind: opInsert, value: 5, ok: true, start: 2, end: 3},
+	}
+	if linearizable(stale) {
+		t.Errorf("Expected %v not to be linearizable", stale)
+	}
+	overlapping := []operation{
+		{kind: opGet, value: 5, ok: true, start: 0, end: 4},
+		{kind: opInsert, value: 5, ok: true, start: 1, end: 2},
+		{kind: opDelete, ok: true, start: 3, end: 5},
+	}
+	if !linearizable(overlapping) {
+		t.Errorf("Expected %v to be linearizable", overlapping)
+	}
+}
+
+// TestLinearizability runs random operations from several goroutines,
+// records when each call started and returned, and checks that every key's
+// history is linearizable. Run it under the race detector as well.
+func TestLinearizability(t *testing.T) {
+	const (
+		rounds     = 50
+		goroutines = 4
+		keys       = 6
+		opsPerKey  = 3 // per goroutine, so each key sees 12 operations
+	)
+	for round := 0; round < rounds; round++ {
+		s := NewConcurrent[int, int](int64(round))
+		var clock atomic.Int64
+		histories := make([][][]operation, goroutines)
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				rng := rand.New(rand.NewSource(int64(round*goroutines + g)))
+				history := make([][]operation, keys)
+				var plan []int
+				for k := 0; k < keys; k++ {
+					for i := 0; i < opsPerKey; i++ {
+						plan = append(plan, k)
+					}
+				}
+				rng.Shuffle(len(plan), func(i, j int) { plan[i], plan[j] = plan[j], plan[i] })
+				for i, key := range plan {
+					o := operation{kind: opKind(rng.Intn(3)), start: clock.Add(1)}
+					switch o.kind {
+					case opInsert:
+						o.value = g*1000 + i
+						o.ok = s.Insert(key, o.value)
+					case opDelete:
+						o.ok = s.Delete(key)
+					case opGet:
+						o.value, o.ok = s.Get(key)
+					}
+					// Exercise the read paths that are not checked, too.
+					s.Floor(key)
+					s.Rank(key)
+					o.end = clock.Add(1)
+					history[key] = append(history[key], o)
+				}
+				histories[g] = history
+			}(g)
+		}
+		wg.Wait()
+
+		present := 0
+		for k := 0; k < keys; k++ {
+			var ops []operation
+			for _, h := range histories {
+				ops = append(ops, h[k]...)
+			}
+			if !linearizable(ops) {
+				t.Fatalf("Round %d: history of key %d is not linearizable: %v", round, k, ops)
+			}
+			if s.Contains(k) {
+				present++
+			}
+		}
+		if s.Len() != present {
+			t.Fatalf("Round %d: Len() = %d, but %d keys are present", round, s.Len(), present)
+		}
+	}
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	s := NewConcurrent[int, int](1)
+	for i := 0; i < 1000; i++ {
+		s.Insert(i, i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if g%2 == 0 {
+					s.Insert(1000+g*500+i, i)
+					s.Delete(i*2 + g)
+					continue
+				}
+				prev := -1
+				s.Range(100, 200, func(k, _ int) bool {
+					if k <= prev {
+						t.Errorf("Range out of order: %d after %d", k, prev)
+					}
+					prev = k
+					return true
+				})
+				if e, ok := s.Ceiling(150); ok && e.Key < 150 {
+					t.Errorf("Ceiling(150) returned %d", e.Key)
+				}
+				s.ByRank(i)
+				for range s.Iterator() {
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	entries := s.Entries()
+	if len(entries) != s.Len() {
+		t.Errorf("Expected %d entries, got %d", s.Len(), len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Key >= entries[i].Key {
+			t.Fatalf("Entries out of order at %d", i)
+		}
+	}
+	checkConcurrentInvariants(t, s)
+}
+
+// checkConcurrentInvariants verifies, once writers have finished, that every
+// node is fully linked and unmarked, that keys are in order, and that each
+// level is a subsequence of the one below made of the nodes tall enough.
+func checkConcurrentInvariants[K, V any](t *testing.T, s *ConcurrentSkipList[K, V]) {
+	t.Helper()
+	below := map[*concurrentNode[K, V]]bool{}
+	for x := s.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+		below[x] = true
+	}
+	if len(below) != s.Len() {
+		t.Fatalf("Expected %d nodes at level 0, Len is %d", s.Len(), len(below))
+	}
+	for i := 0; i < maxLevel; i++ {
+		level := map[*concurrentNode[K, V]]bool{}
+		for x := s.head.next[i].Load(); x != nil; x = x.next[i].Load() {
+			if !x.live() || len(x.next) <= i || !below[x] {
+				t.Fatalf("Level %d holds a node that is deleted, partly linked or missing below", i)
+			}
+			if next := x.next[i].Load(); next != nil && s.compare(x.key, next.key) >= 0 {
+				t.Fatalf("Level %d: keys out of order: %v then %v", i, x.key, next.key)
+			}
+			level[x] = true
+		}
+		for x := range below {
+			if len(x.next) > i && !level[x] {
+				t.Fatalf("Level %d is missing a node of height %d", i, len(x.next))
+			}
+		}
+		below = level
+	}
+}
+
+// TestConcurrentAgainstSkipList runs the same random operations on a
+// ConcurrentSkipList and a SkipList from one goroutine and compares every
+// result.
+func TestConcurrentAgainstSkipList(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	c := NewConcurrent[int, int](1)
+	s := New[int, int](1)
+	for step := 0; step < 20000; step++ {
+		key := rng.Intn(300)
+		switch rng.Intn(4) {
+		case 0, 1:
+			if got, want := c.Insert(key, step), s.Insert(key, step); got != want {
+				t.Fatalf("Step %d: Insert(%d) = %v, want %v", step, key, got, want)
+			}
+		case 2:
+			if got, want := c.Delete(key), s.Delete(key); got != want {
+				t.Fatalf("Step %d: Delete(%d) = %v, want %v", step, key, got, want)
+			}
+		case 3:
+			if step%100 == 0 {
+				c.Clear()
+				s.Clear()
+			}
+		}
+		check := func(name string, got, want any) {
+			t.Helper()
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Step %d: %s(%d) = %v, want %v", step, name, key, got, want)
+			}
+		}
+		probe := key + rng.Intn(21) - 10
+		v1, ok1 := c.Get(probe)
+		v2, ok2 := s.Get(probe)
+		check("Get", []any{v1, ok1}, []any{v2, ok2})
+		e1, ok1 := c.Floor(probe)
+		e2, ok2 := s.Floor(probe)
+		check("Floor", []any{e1, ok1}, []any{e2, ok2})
+		e1, ok1 = c.Ceiling(probe)
+		e2, ok2 = s.Ceiling(probe)
+		check("Ceiling", []any{e1, ok1}, []any{e2, ok2})
+		r1, ok1 := c.Rank(probe)
+		r2, ok2 := s.Rank(probe)
+		check("Rank", []any{r1, ok1}, []any{r2, ok2})
+		e1, ok1 = c.ByRank(probe / 4)
+		e2, ok2 = s.ByRank(probe / 4)
+		check("ByRank", []any{e1, ok1}, []any{e2, ok2})
+		if step%500 == 0 {
+			e1, ok1 = c.Min()
+			e2, ok2 = s.Min()
+			check("Min", []any{e1, ok1}, []any{e2, ok2})
+			e1, ok1 = c.Max()
+			e2, ok2 = s.Max()
+			check("Max", []any{e1, ok1}, []any{e2, ok2})
+			check("Entries", c.Entries(), s.Entries())
+			checkConcurrentInvariants(t, c)
+		}
+		if c.Len() != s.Len() {
+			t.Fatalf("Step %d: Len() = %d, want %d", step, c.Len(), s.Len())
+		}
+	}
+}
+
+// lockedSkipList is a SkipList behind one RWMutex, to compare against.
+type lockedSkipList struct {
+	list *SkipList[int, int]
+	mu   sync.RWMutex
+}
+
+func (l *lockedSkipList) Insert(key, value int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Insert(key, value)
+}
+
+func (l *lockedSkipList) Delete(key int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Delete(key)
+}
+
+func (l *lockedSkipList) Get(key int) (int, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Get(key)
+}
+
+// BenchmarkParallel runs a mix of lookups and updates from every goroutine.
+// Compare the ns/op of the two variants across -cpu 1,2,4,8: the locked
+// list serializes writers behind one mutex, while ConcurrentSkipList only
+// contends on the nodes being changed.
+func BenchmarkParallel(b *testing.B) {
+	type ops interface {
+		Insert(key, value int) bool
+		Delete(key int) bool
+		Get(key int) (int, bool)
+	}
+	const n = 100000
+	for _, bench := range []struct {
+		name string
+		new  func() ops
+	}{
+		{"Concurrent", func() ops { return NewConcurrent[int, int](1) }},
+		{"Locked", func() ops { return &lockedSkipList{list: New[int, int](1)} }},
+	} {
+		for _, writes := range []int{10, 50} {
+			b.Run(fmt.Sprintf("%s/%d%%writes", bench.name, writes), func(b *testing.B) {
+				list := bench.new()
+				for i := 0; i < n; i += 2 {
+					list.Insert(i, i)
+				}
+				var seed atomic.Int64
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					rng := rand.New(rand.NewSource(seed.Add(1)))
+					for pb.Next() {
+						key := rng.Intn(n)
+						switch op := rng.Intn(100); {
+						case op < writes/2:
+							list.Insert(key, op)
+						case op < writes:
+							list.Delete(key)
+						default:
+							list.Get(key)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Package skiplist implements an ordered map as a skip list, with floor and
+// ceiling lookups, range iteration and rank queries in expected O(log n)
+// time. SkipList is not safe for concurrent use; ConcurrentSkipList is.
+package skiplist
+
+import (
+	"cmp"
+	"math/rand"
+)
+
+const (
+	// maxLevel bounds the height of a node. With p = 1/4 it suits lists
+	// of up to 4^32 entries.
+	maxLevel = 32
+	// p is the probability that a node at one level also appears at the
+	// next.
+	p = 0.25
+)
+
+// Entry is a key and its value.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+type node[K, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+	// span[i] is the number of entries next[i] moves forward, counting the
+	// entry it lands on, so summing spans along a search gives a rank.
+	span []int
+}
+
+// SkipList is an ordered map from keys to values. Levels are drawn from a
+// seeded random source, so a list built by the same sequence of operations
+// with the same seed always has the same shape.
+type SkipList[K, V any] struct {
+	head    node[K, V]
+	level   int
+	length  int
+	compare func(a, b K) int
+	rng     *rand.Rand
+}
+
+// New creates and returns a new, empty SkipList for an ordered key type,
+// drawing levels from a random source seeded with seed.
+func New[K cmp.Ordered, V any](seed int64) *SkipList[K, V] {
+	return NewFunc[K, V](cmp.Compare[K], seed)
+}
+
+// NewFunc creates an empty SkipList ordered by compare, which returns a
+// negative number, zero or a positive number when a is less than, equal
+// to or greater than b.
+func NewFunc[K, V any](compare func(a, b K) int, seed int64) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head: node[K, V]{
+			next: make([]*node[K, V], maxLevel),
+			span: make([]int, maxLevel),
+		},
+		level:   1,
+		compare: compare,
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < maxLevel && s.rng.Float64() < p {
+		level++
+	}
+	return level
+}
+
+// search returns, for every level, the last node whose key is less than
+// key, and the rank of each of those nodes, with the head at rank 0.
+func (s *SkipList[K, V]) search(key K) (update [maxLevel]*node[K, V], rank [maxLevel]int) {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && s.compare(x.next[i].key, key) < 0 {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	return update, rank
+}
+
+// find returns the node holding key, or nil.
+func (s *SkipList[K, V]) find(key K) *node[K, V] {
+	update, _ := s.search(key)
+	if x := update[0].next[0]; x != nil && s.compare(x.key, key) == 0 {
+		return x
+	}
+	return nil
+}
+
+// Insert sets the value for key, reporting whether key is new.
+func (s *SkipList[K, V]) Insert(key K, value V) bool {
+	update, rank := s.search(key)
+	if x := update[0].next[0]; x != nil && s.compare(x.key, key) == 0 {
+		x.value = value
+		return false
+	}
+	level := s.randomLevel()
+	for i := s.level; i < level; i++ {
+		update[i], rank[i] = &s.head, 0
+		s.head.span[i] = s.length
+	}
+	s.level = max(s.level, level)
+
+	x := &node[K, V]{key: key, value: value, next: make([]*node[K, V], level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+		// rank[0] - rank[i] entries lie between update[i] and x.
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+	s.length++
+	return true
+}
+
+// Delete removes key, reporting whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	update, _ := s.search(key)
+	x := update[0].next[0]
+	if x == nil || s.compare(x.key, key) != 0 {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+	return true
+}
+
+// Get returns the value for key and whether key is present.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if x := s.find(key); x != nil {
+		return x.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present.
+func (s *SkipList[K, V]) Contains(key K) bool {
+	return s.find(key) != nil
+}
+
+// Floor returns the entry with the greatest key less than or equal to key,
+// and false if there is none.
+func (s *SkipList[K, V]) Floor(key K) (Entry[K, V], bool) {
+	update, _ := s.search(key)
+	if x := update[0].next[0]; x != nil && s.compare(x.key, key) == 0 {
+		return Entry[K, V]{x.key, x.value}, true
+	}
+	if x := update[0]; x != &s.head {
+		return Entry[K, V]{x.key, x.value}, true
+	}
+	return Entry[K, V]{}, false
+}
+
+// Ceiling returns the entry with the least key greater than or equal to
+// key, and false if there is none.
+func (s *SkipList[K, V]) Ceiling(key K) (Entry[K, V], bool) {
+	update, _ := s.search(key)
+	if x := update[0].next[0]; x != nil {
+		return Entry[K, V]{x.key, x.value}, true
+	}
+	return Entry[K, V]{}, false
+}
+
+// Min returns the entry with the least key, and false if the list is empty.
+func (s *SkipList[K, V]) Min() (Entry[K, V], bool) {
+	return s.ByRank(0)
+}
+
+// Max returns the entry with the greatest key, and false if the list is
+// empty.
+func (s *SkipList[K, V]) Max() (Entry[K, V], bool) {
+	return s.ByRank(s.length - 1)
+}
+
+// Rank returns the number of keys less than key, which is key's 0-based
+// position if it is present, and whether it is present.
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	update, rank := s.search(key)
+	x := update[0].next[0]
+	return rank[0], x != nil && s.compare(x.key, key) == 0
+}
+
+// ByRank returns the entry at 0-based position r in key order, and false
+// if r is out of range.
+func (s *SkipList[K, V]) ByRank(r int) (Entry[K, V], bool) {
+	if r < 0 || r >= s.length {
+		return Entry[K, V]{}, false
+	}
+	x, traversed := &s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= r+1 {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+		if traversed == r+1 {
+			break
+		}
+	}
+	return Entry[K, V]{x.key, x.value}, true
+}
+
+// Range calls f for each entry with a key in the half-open interval
+// [lo, hi), in key order, until f returns false.
+func (s *SkipList[K, V]) Range(lo, hi K, f func(key K, value V) bool) {
+	update, _ := s.search(lo)
+	for x := update[0].next[0]; x != nil && s.compare(x.key, hi) < 0; x = x.next[0] {
+		if !f(x.key, x.value) {
+			return
+		}
+	}
+}
+
+// ForEach calls f for every entry in key order.
+func (s *SkipList[K, V]) ForEach(f func(key K, value V)) {
+	for x := s.head.next[0]; x != nil; x = x.next[0] {
+		f(x.key, x.value)
+	}
+}
+
+// Entries returns every entry in key order.
+func (s *SkipList[K, V]) Entries() []Entry[K, V] {
+	result := make([]Entry[K, V], 0, s.length)
+	s.ForEach(func(k K, v V) {
+		result = append(result, Entry[K, V]{k, v})
+	})
+	return result
+}
+
+// Iterator returns a channel that yields the entries in key order, as they
+// were when Iterator was called.
+func (s *SkipList[K, V]) Iterator() <-chan Entry[K, V] {
+	return sendAll(s.Entries())
+}
+
+func sendAll[K, V any](entries []Entry[K, V]) <-chan Entry[K, V] {
+	ch := make(chan Entry[K, V])
+	go func() {
+		for _, e := range entries {
+			ch <- e
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func (s *SkipList[K, V]) Len() int {
+	return s.length
+}
+
+// Clear removes every entry. The random source carries on from its current
+// state.
+func (s *SkipList[K, V]) Clear() {
+	clear(s.head.next)
+	clear(s.head.span)
+	s.level, s.length = 1, 0
+}
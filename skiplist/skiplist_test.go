@@ -0,0 +1,248 @@
+package skiplist
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// checkInvariants verifies ordering and that the spans on every level add
+// up to the ranks found at level 0.
+func checkInvariants[K, V any](t *testing.T, s *SkipList[K, V]) {
+	t.Helper()
+	ranks := map[*node[K, V]]int{&s.head: 0}
+	r := 0
+	for x := s.head.next[0]; x != nil; x = x.next[0] {
+		r++
+		ranks[x] = r
+		if x.next[0] != nil && s.compare(x.key, x.next[0].key) >= 0 {
+			t.Fatalf("Keys out of order: %v then %v", x.key, x.next[0].key)
+		}
+	}
+	if r != s.length {
+		t.Fatalf("Expected %d entries at level 0, length is %d", r, s.length)
+	}
+	for i := 0; i < s.level; i++ {
+		for x := &s.head; x.next[i] != nil; x = x.next[i] {
+			if got := ranks[x] + x.span[i]; got != ranks[x.next[i]] {
+				t.Fatalf("Level %d: span from rank %d reaches %d, want %d", i, ranks[x], got, ranks[x.next[i]])
+			}
+		}
+	}
+	for i := s.level; i < maxLevel; i++ {
+		if s.head.next[i] != nil {
+			t.Fatalf("Level %d is above the list level %d but not empty", i, s.level)
+		}
+	}
+}
+
+func TestAgainstMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New[int, int](1)
+	model := map[int]int{}
+	for step := 0; step < 20000; step++ {
+		key := rng.Intn(500)
+		switch rng.Intn(3) {
+		case 0, 1:
+			_, existed := model[key]
+			if isNew := s.Insert(key, step); isNew == existed {
+				t.Fatalf("Step %d: Insert(%d) reported new = %v", step, key, isNew)
+			}
+			model[key] = step
+		case 2:
+			_, existed := model[key]
+			if s.Delete(key) != existed {
+				t.Fatalf("Step %d: Delete(%d) disagrees with the model", step, key)
+			}
+			delete(model, key)
+		}
+		want, present := model[key]
+		if v, ok := s.Get(key); ok != present || v != want {
+			t.Fatalf("Step %d: Get(%d) = %d, %v; want %d, %v", step, key, v, ok, want, present)
+		}
+		if step%1000 == 0 {
+			checkInvariants(t, s)
+		}
+	}
+	checkInvariants(t, s)
+
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if s.Len() != len(keys) {
+		t.Fatalf("Expected %d entries, got %d", len(keys), s.Len())
+	}
+	for i, k := range keys {
+		if e, ok := s.ByRank(i); !ok || e.Key != k || e.Value != model[k] {
+			t.Fatalf("ByRank(%d) = %v, %v; want key %d", i, e, ok, k)
+		}
+		if r, ok := s.Rank(k); !ok || r != i {
+			t.Fatalf("Rank(%d) = %d, %v; want %d, true", k, r, ok, i)
+		}
+	}
+	for probe := -1; probe <= 500; probe++ {
+		i := sort.SearchInts(keys, probe)
+		if r, ok := s.Rank(probe); r != i || ok != (i < len(keys) && keys[i] == probe) {
+			t.Fatalf("Rank(%d) = %d, %v", probe, r, ok)
+		}
+		ceiling, ok := s.Ceiling(probe)
+		if ok != (i < len(keys)) || ok && ceiling.Key != keys[i] {
+			t.Fatalf("Ceiling(%d) = %v, %v", probe, ceiling, ok)
+		}
+		j := sort.SearchInts(keys, probe+1) - 1
+		floor, ok := s.Floor(probe)
+		if ok != (j >= 0) || ok && floor.Key != keys[j] {
+			t.Fatalf("Floor(%d) = %v, %v", probe, floor, ok)
+		}
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	s := New[int, string](7)
+	for _, k := range []int{10, 20, 30} {
+		s.Insert(k, "v"+strings.Repeat("x", k/10))
+	}
+	tests := []struct {
+		key            int
+		floor, ceiling int // 0 when there is none
+	}{
+		{5, 0, 10}, {10, 10, 10}, {15, 10, 20}, {30, 30, 30}, {35, 30, 0},
+	}
+	for _, tt := range tests {
+		if e, ok := s.Floor(tt.key); ok != (tt.floor != 0) || e.Key != tt.floor {
+			t.Errorf("Floor(%d) = %v, %v; want %d", tt.key, e, ok, tt.floor)
+		}
+		if e, ok := s.Ceiling(tt.key); ok != (tt.ceiling != 0) || e.Key != tt.ceiling {
+			t.Errorf("Ceiling(%d) = %v, %v; want %d", tt.key, e, ok, tt.ceiling)
+		}
+	}
+	if e, ok := s.Min(); !ok || e.Key != 10 || e.Value != "vx" {
+		t.Errorf("Min() = %v, %v; want 10", e, ok)
+	}
+	if e, ok := s.Max(); !ok || e.Key != 30 || e.Value != "vxxx" {
+		t.Errorf("Max() = %v, %v; want 30", e, ok)
+	}
+}
+
+func TestRange(t *testing.T) {
+	s := New[int, int](3)
+	for i := 0; i < 20; i += 2 {
+		s.Insert(i, i*i)
+	}
+	var got []int
+	s.Range(3, 11, func(k, v int) bool {
+		if v != k*k {
+			t.Errorf("Expected value %d for key %d, got %d", k*k, k, v)
+		}
+		got = append(got, k)
+		return true
+	})
+	if !reflect.DeepEqual(got, []int{4, 6, 8, 10}) {
+		t.Errorf("Expected [4 6 8 10], got %v", got)
+	}
+	got = nil
+	s.Range(0, 100, func(k, _ int) bool {
+		got = append(got, k)
+		return len(got) < 3
+	})
+	if !reflect.DeepEqual(got, []int{0, 2, 4}) {
+		t.Errorf("Expected iteration to stop after three keys, got %v", got)
+	}
+	s.Range(11, 3, func(int, int) bool {
+		t.Errorf("Expected an empty range")
+		return true
+	})
+}
+
+func TestEmpty(t *testing.T) {
+	s := New[string, int](1)
+	if _, ok := s.Get("a"); ok {
+		t.Errorf("Expected no entry")
+	}
+	if _, ok := s.Min(); ok {
+		t.Errorf("Expected no minimum")
+	}
+	if _, ok := s.ByRank(0); ok {
+		t.Errorf("Expected ByRank(0) to fail")
+	}
+	if r, ok := s.Rank("a"); r != 0 || ok {
+		t.Errorf("Rank(a) = %d, %v; want 0, false", r, ok)
+	}
+	if s.Delete("a") {
+		t.Errorf("Expected Delete on an empty list to fail")
+	}
+	s.Insert("a", 1)
+	s.Clear()
+	if s.Len() != 0 || s.Contains("a") {
+		t.Errorf("Expected Clear to empty the list")
+	}
+	checkInvariants(t, s)
+}
+
+func TestSeedDeterminesShape(t *testing.T) {
+	shape := func(seed int64) []int {
+		s := New[int, struct{}](seed)
+		for i := 0; i < 200; i++ {
+			s.Insert(i, struct{}{})
+		}
+		var heights []int
+		for x := s.head.next[0]; x != nil; x = x.next[0] {
+			heights = append(heights, len(x.next))
+		}
+		return heights
+	}
+	if !reflect.DeepEqual(shape(42), shape(42)) {
+		t.Errorf("Expected the same seed to build the same shape")
+	}
+	if reflect.DeepEqual(shape(42), shape(43)) {
+		t.Errorf("Expected different seeds to build different shapes")
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	s := NewFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, 1)
+	s.Insert("b", 1)
+	s.Insert("A", 2)
+	s.Insert("B", 3)
+	var keys []string
+	for e := range s.Iterator() {
+		keys = append(keys, e.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"A", "b"}) {
+		t.Errorf("Expected [A b], got %v", keys)
+	}
+	if v, _ := s.Get("a"); v != 2 {
+		t.Errorf("Expected Get(a) = 2, got %d", v)
+	}
+	if v, _ := s.Get("b"); v != 3 {
+		t.Errorf("Expected Get(b) = 3, got %d", v)
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	keys := rng.Perm(b.N)
+	s := New[int, int](1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Insert(keys[i], i)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	const n = 100000
+	s := New[int, int](1)
+	for i := 0; i < n; i++ {
+		s.Insert(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Get(i % n)
+	}
+}
@@ -0,0 +1,198 @@
+// Package topk implements a thread-safe Space-Saving tracker for the most
+// frequent elements of a stream.
+package topk
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrIncompatible is returned when merging trackers of different sizes.
+var ErrIncompatible = errors.New("topk: trackers have different capacities")
+
+// Entry is a tracked element with its estimated count. The true count lies
+// in [Count-Error, Count].
+type Entry[T comparable] struct {
+	Item  T
+	Count uint64
+	Error uint64
+}
+
+// TopK is a thread-safe Space-Saving summary that tracks at most k
+// elements. Any element occurring more than Total()/k times is guaranteed
+// to be tracked.
+type TopK[T comparable] struct {
+	k       int
+	entries entryHeap[T]
+	index   map[T]*heapEntry[T]
+	total   uint64
+	mu      sync.RWMutex
+}
+
+type heapEntry[T comparable] struct {
+	Entry[T]
+	pos int
+}
+
+// entryHeap is a min-heap of entries ordered by count.
+type entryHeap[T comparable] []*heapEntry[T]
+
+func (h entryHeap[T]) Len() int           { return len(h) }
+func (h entryHeap[T]) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h entryHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+func (h *entryHeap[T]) Push(x any) {
+	e := x.(*heapEntry[T])
+	e.pos = len(*h)
+	*h = append(*h, e)
+}
+func (h *entryHeap[T]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// New creates a tracker holding at most k elements.
+func New[T comparable](k int) *TopK[T] {
+	if k < 1 {
+		k = 1
+	}
+	return &TopK[T]{
+		k:     k,
+		index: make(map[T]*heapEntry[T], k),
+	}
+}
+
+// Add records one occurrence of an element.
+func (t *TopK[T]) Add(elem T) {
+	t.AddCount(elem, 1)
+}
+
+// AddCount records n occurrences of an element. When the tracker is full
+// and the element is new, it replaces the element with the smallest count
+// and inherits that count as its error.
+func (t *TopK[T]) AddCount(elem T, n uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total += n
+	t.add(elem, n, 0)
+}
+
+func (t *TopK[T]) add(elem T, n, errBound uint64) {
+	if e, ok := t.index[elem]; ok {
+		e.Count += n
+		e.Error += errBound
+		heap.Fix(&t.entries, e.pos)
+		return
+	}
+	if len(t.entries) < t.k {
+		e := &heapEntry[T]{Entry: Entry[T]{Item: elem, Count: n, Error: errBound}}
+		heap.Push(&t.entries, e)
+		t.index[elem] = e
+		return
+	}
+	e := t.entries[0]
+	delete(t.index, e.Item)
+	e.Item = elem
+	e.Error = e.Count + errBound
+	e.Count += n
+	t.index[elem] = e
+	heap.Fix(&t.entries, 0)
+}
+
+// Count returns the estimated count of an element and whether it is
+// currently tracked.
+func (t *TopK[T]) Count(elem T) (uint64, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if e, ok := t.index[elem]; ok {
+		return e.Count, true
+	}
+	return 0, false
+}
+
+// Top returns the tracked elements sorted by descending count.
+func (t *TopK[T]) Top() []Entry[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]Entry[T], len(t.entries))
+	for i, e := range t.entries {
+		result[i] = e.Entry
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Count > result[j].Count })
+	return result
+}
+
+// Total returns the sum of all counts added.
+func (t *TopK[T]) Total() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.total
+}
+
+// minCount returns the count an untracked element may have at most.
+func (t *TopK[T]) minCount() uint64 {
+	if len(t.entries) < t.k {
+		return 0
+	}
+	return t.entries[0].Count
+}
+
+// Merge folds other into t using the mergeable Space-Saving rule: an
+// element missing from one summary is credited with that summary's
+// minimum count, then the k largest counts are kept.
+func (t *TopK[T]) Merge(other *TopK[T]) error {
+	if t.k != other.k {
+		return ErrIncompatible
+	}
+	// Snapshot other first so the two locks are never held together.
+	other.mu.RLock()
+	theirs := make(map[T]Entry[T], len(other.entries))
+	for _, e := range other.entries {
+		theirs[e.Item] = e.Entry
+	}
+	theirMin, theirTotal := other.minCount(), other.total
+	other.mu.RUnlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ourMin := t.minCount()
+	merged := make([]Entry[T], 0, len(t.entries)+len(theirs))
+	for _, e := range t.entries {
+		entry := e.Entry
+		if o, ok := theirs[e.Item]; ok {
+			entry.Count += o.Count
+			entry.Error += o.Error
+			delete(theirs, e.Item)
+		} else {
+			entry.Count += theirMin
+			entry.Error += theirMin
+		}
+		merged = append(merged, entry)
+	}
+	for _, o := range theirs {
+		o.Count += ourMin
+		o.Error += ourMin
+		merged = append(merged, o)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Count > merged[j].Count })
+	if len(merged) > t.k {
+		merged = merged[:t.k]
+	}
+
+	t.entries = t.entries[:0]
+	t.index = make(map[T]*heapEntry[T], t.k)
+	for _, entry := range merged {
+		e := &heapEntry[T]{Entry: entry}
+		heap.Push(&t.entries, e)
+		t.index[entry.Item] = e
+	}
+	t.total += theirTotal
+	return nil
+}
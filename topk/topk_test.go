@@ -0,0 +1,126 @@
+package topk
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// heavyStream returns a stream where items 0..9 are heavy hitters hidden
+// in uniform noise, and the exact counts.
+func heavyStream(seed int64, n int) ([]int, map[int]uint64) {
+	rng := rand.New(rand.NewSource(seed))
+	stream := make([]int, n)
+	exact := map[int]uint64{}
+	for i := range stream {
+		if rng.Intn(2) == 0 {
+			stream[i] = rng.Intn(10)
+		} else {
+			stream[i] = 10 + rng.Intn(100000)
+		}
+		exact[stream[i]]++
+	}
+	return stream, exact
+}
+
+func checkHeavyHitters(t *testing.T, top []Entry[int], exact map[int]uint64) {
+	t.Helper()
+	found := map[int]bool{}
+	for _, e := range top[:10] {
+		found[e.Item] = true
+		if e.Count < exact[e.Item] || e.Count-e.Error > exact[e.Item] {
+			t.Errorf("Item %d: exact %d outside [%d, %d]", e.Item, exact[e.Item], e.Count-e.Error, e.Count)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if !found[i] {
+			t.Errorf("Expected heavy hitter %d in the top 10, got %v", i, top[:10])
+		}
+	}
+}
+
+func TestHeavyHitters(t *testing.T) {
+	stream, exact := heavyStream(1, 100000)
+	tracker := New[int](50)
+	for _, v := range stream {
+		tracker.Add(v)
+	}
+	top := tracker.Top()
+	if len(top) != 50 {
+		t.Fatalf("Expected 50 entries, got %d", len(top))
+	}
+	if !sort.SliceIsSorted(top, func(i, j int) bool { return top[i].Count > top[j].Count }) {
+		t.Errorf("Expected Top to be sorted by descending count")
+	}
+	checkHeavyHitters(t, top, exact)
+}
+
+func TestMerge(t *testing.T) {
+	exact := map[int]uint64{}
+	shards := make([]*TopK[int], 4)
+	var wg sync.WaitGroup
+	for s := range shards {
+		shards[s] = New[int](50)
+		stream, counts := heavyStream(int64(s), 25000)
+		for v, c := range counts {
+			exact[v] += c
+		}
+		wg.Add(1)
+		go func(tracker *TopK[int]) {
+			defer wg.Done()
+			for _, v := range stream {
+				tracker.Add(v)
+			}
+		}(shards[s])
+	}
+	wg.Wait()
+
+	total := New[int](50)
+	for _, shard := range shards {
+		if err := total.Merge(shard); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if total.Total() != 100000 {
+		t.Errorf("Expected total 100000, got %d", total.Total())
+	}
+	checkHeavyHitters(t, total.Top(), exact)
+
+	if err := total.Merge(New[int](10)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tracker := New[string](2)
+	tracker.AddCount("a", 5)
+	tracker.AddCount("b", 3)
+	tracker.Add("c")
+	if c, ok := tracker.Count("c"); !ok || c != 4 {
+		t.Errorf("Expected c to replace b with count 4, got %d %v", c, ok)
+	}
+	if _, ok := tracker.Count("b"); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	tracker := New[int](10)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				tracker.Add(i % 5)
+				tracker.Top()
+			}
+		}()
+	}
+	wg.Wait()
+	if c, _ := tracker.Count(0); c != 1600 {
+		t.Errorf("Expected count 1600, got %d", c)
+	}
+}